@@ -0,0 +1,70 @@
+package apps
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"testing"
+
+	"github.com/cloudfoundry/cf-test-helpers/v2/workflowhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/cf-performance-tests/helpers"
+)
+
+var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
+var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
+var ccdb *sql.DB
+var uaadb *sql.DB
+var ctx context.Context
+
+// the regular user, who only sees the apps in the spaces it is developer in
+var regularUserGUID string
+
+const test_version = "v1"
+
+var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+	// the filters contain as many spaces and app names as given by large_elements_filter
+	Expect(dataset.Int("spaces_assigned_to_regular_user")).To(BeNumerically(">=", testConfig.LargeElementsFilter))
+
+	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
+	testSetup.Setup()
+	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
+
+	regularUserGUID = helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
+
+	helpers.AnalyzeDB(ccdb, ctx, testConfig)
+})
+
+var _ = AfterSuite(func() {
+	helpers.CleanupTestData(ccdb, uaadb, ctx, testConfig)
+
+	err := ccdb.Close()
+	if err != nil {
+		log.Print(err)
+	}
+
+	if uaadb != nil {
+		err = uaadb.Close()
+		if err != nil {
+			log.Print(err)
+		}
+	}
+})
+
+var _ = ReportAfterSuite("Apps test suite", func(report types.Report) {
+	helpers.GenerateReports(helpers.ConfigureJsonReporter(&testConfig, "apps", "apps", test_version), report)
+})
+
+func TestApps(t *testing.T) {
+	helpers.LoadConfig(&testConfig)
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Apps Suite")
+}
@@ -0,0 +1,260 @@
+package apps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/cf-performance-tests/helpers"
+	"github.com/cloudfoundry/cf-test-helpers/v2/workflowhelpers"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+var _ = Describe("apps", func() {
+	Describe("GET /v3/apps", func() {
+		// the apps and spaces of the filters are in the spaces the regular user is developer in, so that they match
+		// apps for both users
+		var appNamesList []string
+		var spaceGuidsList []string
+		BeforeEach(func() {
+			appNamesList = getRandomAppNames()
+			spaceGuidsList = getRandomSpaceGuids()
+		})
+
+		Context("as admin", func() {
+			It("get all apps", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps::as admin")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps")
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("get all apps with page size %d", testConfig.LargePageSize), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps::as admin with page size %d", testConfig.LargePageSize))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?per_page=%d", testConfig.LargePageSize))
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("filter by %d names", testConfig.LargeElementsFilter), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps?names=::as admin with %d names", testConfig.LargeElementsFilter))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?names=", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?names=%s", strings.Join(appNamesList, ",")))
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("filter by %d spaces", testConfig.LargeElementsFilter), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps?space_guids=::as admin with %d spaces", testConfig.LargeElementsFilter))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?space_guids=", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?space_guids=%s", strings.Join(spaceGuidsList, ",")))
+						})
+					})
+				})
+			})
+
+			It("filter by label", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?label_selector=::as admin")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?label_selector=env=production", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?label_selector=env=production")
+						})
+					})
+				})
+			})
+
+			It("include space and org", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?include=space.organization::as admin")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?include=space.organization", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?include=space.organization")
+						})
+					})
+				})
+			})
+
+			It("order by name", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?order_by=name::as admin")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?order_by=name", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?order_by=name")
+						})
+					})
+				})
+			})
+
+			It("order by updated_at descending", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?order_by=-updated_at::as admin")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?order_by=-updated_at", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?order_by=-updated_at")
+						})
+					})
+				})
+			})
+		})
+
+		Context("as regular user", func() {
+			It("get all apps", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps::as regular user")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps")
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("get all apps with page size %d", testConfig.LargePageSize), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps::as regular user with page size %d", testConfig.LargePageSize))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?per_page=%d", testConfig.LargePageSize))
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("filter by %d names", testConfig.LargeElementsFilter), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps?names=::as regular user with %d names", testConfig.LargeElementsFilter))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?names=", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?names=%s", strings.Join(appNamesList, ",")))
+						})
+					})
+				})
+			})
+
+			It(fmt.Sprintf("filter by %d spaces", testConfig.LargeElementsFilter), func() {
+				experiment := gmeasure.NewExperiment(fmt.Sprintf("GET /v3/apps?space_guids=::as regular user with %d spaces", testConfig.LargeElementsFilter))
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?space_guids=", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/apps?space_guids=%s", strings.Join(spaceGuidsList, ",")))
+						})
+					})
+				})
+			})
+
+			It("filter by label", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?label_selector=::as regular user")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?label_selector=env=production", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?label_selector=env=production")
+						})
+					})
+				})
+			})
+
+			It("include space and org", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?include=space.organization::as regular user")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?include=space.organization", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?include=space.organization")
+						})
+					})
+				})
+			})
+
+			It("order by name", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?order_by=name::as regular user")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?order_by=name", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?order_by=name")
+						})
+					})
+				})
+			})
+
+			It("order by updated_at descending", func() {
+				experiment := gmeasure.NewExperiment("GET /v3/apps?order_by=-updated_at::as regular user")
+				AddReportEntry(experiment.Name, experiment)
+
+				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
+						experiment.MeasureDuration("GET /v3/apps?order_by=-updated_at", func() {
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/apps?order_by=-updated_at")
+						})
+					})
+				})
+			})
+		})
+	})
+})
+
+// getRandomAppNames returns the names of random apps in the spaces the regular user is developer in.
+func getRandomAppNames() []string {
+	var appNamesList []string
+	appStatement := fmt.Sprintf("SELECT apps.name FROM apps JOIN spaces ON apps.space_guid = spaces.guid JOIN spaces_developers ON spaces.id = spaces_developers.space_id WHERE spaces_developers.user_id = (SELECT id FROM users WHERE guid = '%s') AND apps.name LIKE '%s-app-%%' ORDER BY %s LIMIT %d",
+		regularUserGUID, testConfig.GetNamePrefix(), helpers.GetRandomFunction(testConfig), testConfig.LargeElementsFilter)
+	appNames := helpers.ExecuteSelectStatement(ccdb, ctx, appStatement)
+	for _, name := range appNames {
+		appNamesList = append(appNamesList, helpers.ConvertToString(name))
+	}
+	return appNamesList
+}
+
+// getRandomSpaceGuids returns random spaces the regular user is developer in.
+func getRandomSpaceGuids() []string {
+	var spaceGuidsList []string
+	spaceStatement := fmt.Sprintf("SELECT spaces.guid FROM spaces JOIN spaces_developers ON spaces.id = spaces_developers.space_id WHERE spaces_developers.user_id = (SELECT id FROM users WHERE guid = '%s') AND spaces.name LIKE '%s-space-%%' ORDER BY %s LIMIT %d",
+		regularUserGUID, testConfig.GetNamePrefix(), helpers.GetRandomFunction(testConfig), testConfig.LargeElementsFilter)
+	spaceGuids := helpers.ExecuteSelectStatement(ccdb, ctx, spaceStatement)
+	for _, guid := range spaceGuids {
+		spaceGuidsList = append(spaceGuidsList, helpers.ConvertToString(guid))
+	}
+	return spaceGuidsList
+}
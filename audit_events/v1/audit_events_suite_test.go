@@ -17,6 +17,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var prefix string
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
@@ -25,49 +26,22 @@ var ctx context.Context
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	orgs   = 4
-	spaces = 1
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
 
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	prefix = testConfig.GetNamePrefix()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	// create orgs
-	createOrgStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgStatement, testConfig)
-
-	// copy ids of orgs relevant for regular user
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
-
-	//create spaces
-	createSpacesStatement := fmt.Sprintf("create_spaces(%d)", spaces)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createSpacesStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
-	// create events helper table
-	createEventsTableStatement := fmt.Sprintf("create_event_types_table()")
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createEventsTableStatement, testConfig)
-
-	// create events
-	createEventStatement := fmt.Sprintf("create_events()")
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createEventStatement, testConfig)
+	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	// create one app to have a target_guid for the test
 	createApps(1)
 
-	// assign the regular user to all orgs
-	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	orgsAssignedToRegularUser := orgs
-	assignUserAsOrgManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_managers", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgManager, testConfig)
-
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
 
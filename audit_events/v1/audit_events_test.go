@@ -24,11 +24,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/audit_events")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/audit_events")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -37,11 +37,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/audit_events")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/audit_events")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -50,11 +50,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -63,11 +63,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=5&order_by=-created_at", eventTypes))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=5&order_by=-created_at", eventTypes))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -76,11 +76,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=50&order_by=-created_at", eventTypes))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=50&order_by=-created_at", eventTypes))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -89,11 +89,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=%d", eventTypes, testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?types=%s&per_page=%d", eventTypes, testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -104,11 +104,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?target_guids=%s&page=1&per_page=5&order_by=-created_at", appGuids))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?target_guids=%s&page=1&per_page=5&order_by=-created_at", appGuids))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -117,18 +117,18 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/audit_events?types=audit.organization.update&created_ats[gt]=2022-11-14T08:13:01Z")
+						helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/audit_events?types=audit.organization.update&created_ats[gt]=2022-11-14T08:13:01Z")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
 		It("as admin getting the last page", func() {
 			var pages int
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "/v3/audit_events")
+				exitCode, body := helpers.CCRequestReturning(testConfig.LongTimeout, "/v3/audit_events")
 				Expect(exitCode).To(Equal(0))
 				Expect(body).To(ContainSubstring("200 OK"))
 				response := helpers.ParseResponseBody(helpers.RemoveDebugOutput(body))
@@ -139,11 +139,11 @@ var _ = Describe("audit_events", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/audit_events", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?page=%d", pages))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/audit_events?page=%d", pages))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -0,0 +1,123 @@
+// perf-compare compares result files written by helpers.GenerateReports against a baseline result file. Files of
+// older schema versions are upgraded when reading them (see the results package).
+//
+// Usage:
+//
+//	perf-compare [-threshold 0.1] [-alpha 0.05] [-statistic median] <baseline.json> <result.json> [<result.json>...]
+//
+// Experiments are matched by their `testHeadlineName::experiment` key. An experiment regresses if its statistic
+// grew by more than the threshold compared to the baseline and a Mann-Whitney U test on the raw results considers
+// the difference significant. The command exits with status 1 if any experiment regressed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+var statistics = map[string]func(m results.Measurement) float64{
+	"mean":   func(m results.Measurement) float64 { return m.Average },
+	"median": func(m results.Measurement) float64 { return m.Median },
+	"p90":    func(m results.Measurement) float64 { return m.P90 },
+	"p95":    func(m results.Measurement) float64 { return m.P95 },
+	"p99":    func(m results.Measurement) float64 { return m.P99 },
+	"max":    func(m results.Measurement) float64 { return m.Largest },
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 0.1, "relative increase of the statistic that counts as regression, e.g. 0.1 for 10%")
+	alpha := flag.Float64("alpha", 0.05, "significance level of the Mann-Whitney U test")
+	statisticName := flag.String("statistic", "median", "statistic to compare: mean, median, p90, p95, p99 or max")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <baseline.json> <result.json> [<result.json>...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	statistic, ok := statistics[*statisticName]
+	if !ok || flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	baseline, err := results.Read(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	regressed := false
+	for _, file := range flag.Args()[1:] {
+		result, err := results.Read(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s (CAPI %s) compared to %s (CAPI %s):\n", file, result.CapiVersion, flag.Arg(0), baseline.CapiVersion)
+		if compare(baseline, result, statistic, *statisticName, *threshold, *alpha) {
+			regressed = true
+		}
+		fmt.Println()
+	}
+
+	if regressed {
+		fmt.Printf("At least one experiment regressed by more than %.1f%%.\n", *threshold*100)
+		os.Exit(1)
+	}
+}
+
+// compare prints the change of every experiment and returns whether any experiment regressed.
+func compare(baseline, result *results.File, statistic func(results.Measurement) float64, statisticName string, threshold, alpha float64) bool {
+	var keys []string
+	for key := range baseline.Measurements {
+		keys = append(keys, key)
+	}
+	for key := range result.Measurements {
+		if _, found := baseline.Measurements[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	regressed := false
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "EXPERIMENT\tBASELINE %s\t%s\tCHANGE\tP-VALUE\tVERDICT\n", statisticName, statisticName)
+	for _, key := range keys {
+		before, inBaseline := baseline.Measurements[key][results.RequestTimeMeasurement]
+		after, inResult := result.Measurements[key][results.RequestTimeMeasurement]
+		switch {
+		case !inBaseline:
+			fmt.Fprintf(w, "%s\t-\t%.4fs\t-\t-\tnew\n", key, statistic(after))
+			continue
+		case !inResult:
+			fmt.Fprintf(w, "%s\t%.4fs\t-\t-\t-\tmissing\n", key, statistic(before))
+			continue
+		}
+
+		beforeValue, afterValue := statistic(before), statistic(after)
+		change := 0.0
+		if beforeValue > 0 {
+			change = (afterValue - beforeValue) / beforeValue
+		}
+		_, pValue := results.MannWhitneyU(before.Results, after.Results)
+
+		verdict := "unchanged"
+		significant := pValue < alpha
+		switch {
+		case change > threshold && significant:
+			verdict = "REGRESSED"
+			regressed = true
+		case change < -threshold && significant:
+			verdict = "improved"
+		case change > threshold || change < -threshold:
+			verdict = "not significant"
+		}
+		fmt.Fprintf(w, "%s\t%.4fs\t%.4fs\t%+.1f%%\t%.4f\t%s\n", key, beforeValue, afterValue, change*100, pValue, verdict)
+	}
+	w.Flush()
+	return regressed
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	chartWidth  = 900
+	chartHeight = 260
+	// space for the axis labels around the plot
+	marginLeft   = 70
+	marginRight  = 20
+	marginTop    = 30
+	marginBottom = 40
+)
+
+type chart struct {
+	Folder     string
+	Experiment string
+	SVG        template.HTML
+}
+
+type report struct {
+	ResultsFolder string
+	Charts        []chart
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>cf-performance-tests trends</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+h2 { font-size: 1em; margin-bottom: 0.2em; }
+.folder { color: #666; font-weight: normal; }
+.legend span { margin-right: 1.5em; }
+svg text { font-size: 11px; }
+</style>
+</head>
+<body>
+<h1>Trends of {{.ResultsFolder}}</h1>
+<p class="legend"><span style="color:#1f77b4">&#9632; median</span><span style="color:#d62728">&#9632; p95</span><span style="color:#888">&#9474; CAPI or cf-deployment version changed</span></p>
+{{range .Charts}}
+<h2>{{.Experiment}} <span class="folder">{{.Folder}}</span></h2>
+{{.SVG}}
+{{else}}
+<p>No result files found.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// trendSVG plots the median and the 95th percentile of the runs over time, and marks the runs with a different
+// CAPI or cf-deployment version than the run before.
+func trendSVG(runs []run) template.HTML {
+	first, last := runs[0].timestamp, runs[len(runs)-1].timestamp
+	maxValue := 0.0
+	for _, r := range runs {
+		maxValue = math.Max(maxValue, math.Max(r.median, r.p95))
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+	x := func(timestamp int64) float64 {
+		if first == last {
+			return marginLeft + plotWidth/2
+		}
+		return marginLeft + float64(timestamp-first)/float64(last-first)*plotWidth
+	}
+	y := func(value float64) float64 {
+		return marginTop + plotHeight - value/maxValue*plotHeight
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`, chartWidth, chartHeight)
+	fmt.Fprintf(&svg, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#000"/>`, marginLeft, y(0), chartWidth-marginRight, y(0))
+	fmt.Fprintf(&svg, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" stroke="#000"/>`, marginLeft, marginTop, marginLeft, y(0))
+	for _, value := range []float64{0, maxValue / 2, maxValue} {
+		fmt.Fprintf(&svg, `<text x="%d" y="%.1f" text-anchor="end">%.3fs</text>`, marginLeft-5, y(value)+4, value)
+	}
+	fmt.Fprintf(&svg, `<text x="%d" y="%d">%s</text>`, marginLeft, chartHeight-10, formatTime(first))
+	fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end">%s</text>`, chartWidth-marginRight, chartHeight-10, formatTime(last))
+
+	for i, r := range runs {
+		if i == 0 || r.capiVersion != runs[i-1].capiVersion || r.cfDeploymentVersion != runs[i-1].cfDeploymentVersion {
+			fmt.Fprintf(&svg, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%.1f" stroke="#888" stroke-dasharray="4 3"/>`, x(r.timestamp), marginTop, x(r.timestamp), y(0))
+			fmt.Fprintf(&svg, `<text x="%.1f" y="%d" fill="#555">%s</text>`, x(r.timestamp)+3, marginTop-8,
+				template.HTMLEscapeString(fmt.Sprintf("CAPI %s / cf-d %s", r.capiVersion, r.cfDeploymentVersion)))
+		}
+	}
+
+	series := []struct {
+		color string
+		name  string
+		value func(r run) float64
+	}{
+		{"#1f77b4", "median", func(r run) float64 { return r.median }},
+		{"#d62728", "p95", func(r run) float64 { return r.p95 }},
+	}
+	for _, s := range series {
+		var points []string
+		for _, r := range runs {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(r.timestamp), y(s.value(r))))
+		}
+		fmt.Fprintf(&svg, `<polyline points="%s" fill="none" stroke="%s"/>`, strings.Join(points, " "), s.color)
+		for _, r := range runs {
+			fmt.Fprintf(&svg, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s</title></circle>`, x(r.timestamp), y(s.value(r)), s.color,
+				template.HTMLEscapeString(fmt.Sprintf("%s %s: %.4fs (CAPI %s, cf-deployment %s)", formatTime(r.timestamp), s.name, s.value(r), r.capiVersion, r.cfDeploymentVersion)))
+		}
+	}
+	svg.WriteString(`</svg>`)
+	return template.HTML(svg.String())
+}
+
+func formatTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format("2006-01-02 15:04")
+}
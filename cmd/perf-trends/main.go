@@ -0,0 +1,100 @@
+// perf-trends writes a self-contained HTML report with the trends of all experiments in a results folder.
+//
+// Usage:
+//
+//	perf-trends [-results test-results] [-output perf-trends.html]
+//
+// The result files are read from the <suite>-test-results/<test version>/ folders written by the test suites. For
+// every suite, test version and experiment, the median and the 95th percentile of the request time are plotted
+// over the time of the runs. Runs with a different capiVersion or cfDeploymentVersion than the run before are
+// marked in the charts.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+// run is the request time of an experiment in one result file.
+type run struct {
+	timestamp           int64
+	capiVersion         string
+	cfDeploymentVersion string
+	median              float64
+	p95                 float64
+}
+
+// trend are the runs of an experiment of a suite and test version, ordered by time.
+type trend struct {
+	Folder     string
+	Experiment string
+	runs       []run
+}
+
+func main() {
+	resultsFolder := flag.String("results", "test-results", "results folder of the tests")
+	output := flag.String("output", "perf-trends.html", "HTML file to write")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	trends := map[string]*trend{}
+	err := results.Walk(*resultsFolder, func(path string, file *results.File) error {
+		folder, err := filepath.Rel(*resultsFolder, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		for _, experiment := range file.Experiments() {
+			requestTime, found := experiment.RequestTime()
+			if !found {
+				continue
+			}
+			key := folder + "\x00" + experiment.Key
+			if trends[key] == nil {
+				trends[key] = &trend{Folder: folder, Experiment: experiment.Key}
+			}
+			trends[key].runs = append(trends[key].runs, run{
+				timestamp:           file.Timestamp,
+				capiVersion:         file.CapiVersion,
+				cfDeploymentVersion: file.CfDeploymentVersion,
+				median:              requestTime.Median,
+				p95:                 requestTime.P95,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var keys []string
+	for key, t := range trends {
+		sort.Slice(t.runs, func(i, j int) bool { return t.runs[i].timestamp < t.runs[j].timestamp })
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var charts []chart
+	for _, key := range keys {
+		charts = append(charts, chart{Folder: trends[key].Folder, Experiment: trends[key].Experiment, SVG: trendSVG(trends[key].runs)})
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	err = reportTemplate.Execute(f, report{ResultsFolder: *resultsFolder, Charts: charts})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Wrote the trends of %d experiments to %s\n", len(charts), *output)
+}
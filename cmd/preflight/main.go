@@ -0,0 +1,49 @@
+// preflight checks the configuration of the performance tests before running them.
+//
+// Usage:
+//
+//	preflight [-offline] [--<config key>=<value>...]
+//
+// The configuration is read like by the test suites, from config.yml in the working directory, the project's root
+// folder or $HOME/.cf-performance-tests, overridden by CF_PERF_* environment variables and flags. All problems
+// are listed at once: missing required keys, values out of range, unparseable database connection strings, and,
+// unless -offline is given, failures to connect to the CCDB, the UAADB and the API. The command exits with status
+// 1 if any problem was found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+
+	"github.com/cloudfoundry/cf-performance-tests/helpers"
+)
+
+func main() {
+	testConfig := helpers.NewConfig()
+	offline := flag.Bool("offline", false, "only validate the configuration, without connecting to the databases and the API")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-offline] [--<config key>=<value>...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	viper.AddConfigPath(".")
+	helpers.ReadConfig(&testConfig)
+
+	problems := helpers.ValidateConfig(testConfig)
+	if len(problems) == 0 && !*offline {
+		problems = helpers.CheckConnectivity(testConfig)
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("Found %d problem(s):\n", len(problems))
+		for _, problem := range problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+		os.Exit(1)
+	}
+	fmt.Println("Configuration OK.")
+}
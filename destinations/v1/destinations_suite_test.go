@@ -5,8 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"testing"
 	"os"
+	"testing"
 
 	"github.com/google/uuid"
 
@@ -20,6 +20,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -34,9 +35,6 @@ const test_version = "v1"
 
 // diego seems to have a limitation here
 // when binding more routes to an app the app does not start, or it will fail during staging already
-const (
-	routeMappings = 680
-)
 
 func setupAppAndSeedDB(appName string, spaceGuid string) string {
 	data := fmt.Sprintf(`{
@@ -50,7 +48,7 @@ func setupAppAndSeedDB(appName string, spaceGuid string) string {
                            }
                          }`, appName, spaceGuid)
 
-	exitCode, appCreateBody := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "POST", "/v3/apps", "-d", data)
+	exitCode, appCreateBody := helpers.CCRequestReturning(testConfig.LongTimeout, "-X", "POST", "/v3/apps", "-d", data)
 
 	Expect(exitCode).To(Equal(0))
 	Expect(appCreateBody).To(ContainSubstring("201 Created"))
@@ -58,10 +56,9 @@ func setupAppAndSeedDB(appName string, spaceGuid string) string {
 	appCreateResponse := helpers.ParseCreateResponseBody(helpers.RemoveDebugOutput(appCreateBody))
 	appGuid := appCreateResponse.GUID
 
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
-	createRouteMappingsStatement := fmt.Sprintf("create_routes_and_route_mappings_for_app('%s', '%s', '%s', %d)", appGuid, testSetup.GetOrganizationName(), spaceGuid, routeMappings)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createRouteMappingsStatement, testConfig)
+	seeder.CreateRoutesAndRouteMappingsForApp(appGuid, testSetup.GetOrganizationName(), spaceGuid, dataset.Int("route_mappings"))
 
 	log.Printf("Preparing app directory and files.")
 	appDir := helpers.CreateAppFolder(appName1)
@@ -77,9 +74,12 @@ func setupAppAndSeedDB(appName string, spaceGuid string) string {
 }
 
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
+	helpers.RequireSeederSchemaVersion()
 
 	spaceName := testSetup.TestSpace.SpaceName()
 	spaceGuids := helpers.GetGUIDs(testSetup.AdminUserContext(), testConfig, fmt.Sprintf("/v3/spaces?names=%s", spaceName))
@@ -97,10 +97,10 @@ var _ = BeforeSuite(func() {
 
 var _ = AfterSuite(func() {
 	log.Printf("Deleting app `%s`\n", appName1)
-	helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/apps/%s", appGuid1))
+	helpers.CCRequestReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/apps/%s", appGuid1))
 
 	log.Printf("Deleting app `%s`\n", appName2)
-	helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/apps/%s", appGuid2))
+	helpers.CCRequestReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/apps/%s", appGuid2))
 
 	log.Printf("Starting cleanup testdata...")
 	helpers.CleanupTestData(ccdb, uaadb, ctx, testConfig)
@@ -40,7 +40,7 @@ var _ = Describe("destinations", func() {
                                            }
                                          }`, host, domainGUID, spaceGuid)
 
-					exitCode, body := helpers.TimeCFCurlReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/routes")
+					exitCode, body := helpers.CCRequestReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/routes")
 
 					Expect(exitCode).To(Equal(0))
 					Expect(body).To(ContainSubstring("201 Created"))
@@ -54,7 +54,7 @@ var _ = Describe("destinations", func() {
 		AfterEach(func() {
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
 				for _, routeGUID := range routeGUIDs {
-					helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/routes/%s", routeGUID))
+					helpers.CCRequestReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/routes/%s", routeGUID))
 					helpers.WaitToFail(testSetup.AdminUserContext(), testConfig, fmt.Sprintf("/v3/routes/%s", routeGUID))
 				}
 			})
@@ -66,15 +66,15 @@ var _ = Describe("destinations", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("POST /v3/routes/:guid/destinations", func() {
 							data := fmt.Sprintf(`{"destinations": [ { "app": { "guid": "%s"} } ] }`, appGuid1)
-							exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
+							exitCode, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
 
 							Expect(exitCode).To(Equal(0))
 							Expect(body).To(ContainSubstring("200 OK"))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -83,15 +83,15 @@ var _ = Describe("destinations", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("PATCH /v3/routes/:guid/destinations", func() {
 							data := fmt.Sprintf(`{"destinations": [ { "app": { "guid": "%s"} } ] }`, appGuid1)
-							exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
+							exitCode, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
 
 							Expect(exitCode).To(Equal(0))
 							Expect(body).To(ContainSubstring("200 OK"))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -100,9 +100,9 @@ var _ = Describe("destinations", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						data := fmt.Sprintf(`{"destinations": [ { "app": { "guid": "%s"} } ] }`, appGuid1)
-						exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
+						exitCode, body := helpers.CCRequestReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
 
 						Expect(exitCode).To(Equal(0))
 						Expect(body).To(ContainSubstring("200 OK"))
@@ -111,12 +111,12 @@ var _ = Describe("destinations", func() {
 						destinationGuid := response.Destinations[0].GUID
 
 						experiment.MeasureDuration("DELETE /v3/routes/:guid/destinations/:guid", func() {
-							exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/routes/%s/destinations/%s", routeGUIDs[idx], destinationGuid))
+							exitCode, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, "-X", "DELETE", fmt.Sprintf("/v3/routes/%s/destinations/%s", routeGUIDs[idx], destinationGuid))
 
 							Expect(exitCode).To(Equal(0))
 							Expect(body).To(ContainSubstring("204 No Content"))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -127,15 +127,15 @@ var _ = Describe("destinations", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("POST /v3/routes/:guid/destinations", func() {
 							data := fmt.Sprintf(`{"destinations": [ { "app": { "guid": "%s"} }, { "app": { "guid": "%s"} } ] }`, appGuid1, appGuid2)
-							exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
+							exitCode, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, "-X", "POST", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
 
 							Expect(exitCode).To(Equal(0))
 							Expect(body).To(ContainSubstring("200 OK"))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -144,15 +144,15 @@ var _ = Describe("destinations", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("PATCH /v3/routes/:guid/destinations", func() {
 							data := fmt.Sprintf(`{"destinations": [ { "app": { "guid": "%s"} }, { "app": { "guid": "%s"} } ] }`, appGuid1, appGuid2)
-							exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
+							exitCode, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/routes/%s/destinations", routeGUIDs[idx]))
 
 							Expect(exitCode).To(Equal(0))
 							Expect(body).To(ContainSubstring("200 OK"))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -3,7 +3,6 @@ package domains
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,42 +23,18 @@ var ctx context.Context
 
 const test_version = "v2"
 
-const (
-	// main test parameters:
-	orgs           = 20000
-	sharedDomains  = 100
-	privateDomains = 400
-)
-
 var _ = BeforeSuite(func() {
-	Expect(sharedDomains + privateDomains).To(BeNumerically(">=", testConfig.LargePageSize))
+	dataset = helpers.LoadDataset(testConfig)
+	Expect(dataset.Int("shared_domains") + dataset.Int("private_domains")).To(BeNumerically(">=", testConfig.LargePageSize))
 
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	// create orgs
-	createOrgStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgStatement, testConfig)
-
-	// copy ids of orgs relevant for regular user
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
-
-	// create shared domains
-	createSharedDomainsStatement := fmt.Sprintf("create_shared_domains(%d)", sharedDomains)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createSharedDomainsStatement, testConfig)
-
-	// create private domains; evenly assigned to random orgs
-	createPrivateDomainsStatement := fmt.Sprintf("create_private_domains(%d)", privateDomains)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createPrivateDomainsStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
-	// assign the regular user to all orgs
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	orgsAssignedToRegularUser := orgs
-	assignUserAsOrgManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_managers", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgManager, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
@@ -18,11 +18,11 @@ var _ = Describe("domains", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/domains", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/domains")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/domains")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -31,11 +31,11 @@ var _ = Describe("domains", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/domains", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/domains")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/domains")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -44,11 +44,11 @@ var _ = Describe("domains", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/domains", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/domains?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/domains?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -59,13 +59,13 @@ var _ = Describe("domains", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					orgGUID := getRandomOrgWithPrivateDomain()
 
 					experiment.MeasureDuration("GET /v3/organizations/:guid/domains", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/organizations/%s/domains", orgGUID))
+						helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/organizations/%s/domains", orgGUID))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -74,13 +74,13 @@ var _ = Describe("domains", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					orgGUID := getRandomOrgWithPrivateDomain()
 
 					experiment.MeasureDuration("GET /v3/organizations/:guid/domains", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/organizations/%s/domains", orgGUID))
+						helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/organizations/%s/domains", orgGUID))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -92,13 +92,13 @@ var _ = Describe("domains", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						domainGUID := getRandomPrivateDomain()
 
 						experiment.MeasureDuration("GET /v3/domains/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/domains/%s", domainGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/domains/%s", domainGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -107,14 +107,14 @@ var _ = Describe("domains", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						domainGUID := getRandomPrivateDomain()
 
 						experiment.MeasureDuration("PATCH /v3/domains/:guid", func() {
 							data := `{ "metadata": { "annotations": { "test": "PATCH /v3/domains/:guid" } } }`
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/domains/%s", domainGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/domains/%s", domainGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -123,15 +123,15 @@ var _ = Describe("domains", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						domainGUID := getRandomPrivateDomain()
 
 						experiment.MeasureDuration("DELETE /v3/domains/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "-X", "DELETE", fmt.Sprintf("/v3/domains/%s", domainGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "-X", "DELETE", fmt.Sprintf("/v3/domains/%s", domainGUID))
 						})
 
 						helpers.WaitToFail(testSetup.AdminUserContext(), testConfig, fmt.Sprintf("/v3/domains/%s", domainGUID))
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -142,13 +142,13 @@ var _ = Describe("domains", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						domainGUID := getRandomPrivateDomain()
 
 						experiment.MeasureDuration("GET /v3/domains/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/domains/%s", domainGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/domains/%s", domainGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
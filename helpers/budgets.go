@@ -0,0 +1,207 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/spf13/viper"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+// Budget limits the request time of the experiments matching its pattern, e.g.
+//
+//	budgets:
+//	  - experiment: "GET /v3/roles::as regular user*"
+//	    p95: 2s
+//	    mean: 1s
+//	    max: 5s
+//
+// The pattern is matched against the experiment name and against "<test headline>::<experiment name>"; * matches
+// any text. Thresholds are given in seconds or with a unit, and may be omitted.
+type Budget struct {
+	Experiment string
+	P95        time.Duration
+	Mean       time.Duration
+	Max        time.Duration
+	pattern    *regexp.Regexp
+}
+
+// the statistics of the request time limited by a budget
+var budgetStatistics = []struct {
+	name      string
+	threshold func(b Budget) time.Duration
+	statistic func(m Measurement) float64
+}{
+	{"p95", func(b Budget) time.Duration { return b.P95 }, func(m Measurement) float64 { return m.P95 }},
+	{"mean", func(b Budget) time.Duration { return b.Mean }, func(m Measurement) float64 { return m.Average }},
+	{"max", func(b Budget) time.Duration { return b.Max }, func(m Measurement) float64 { return m.Largest }},
+}
+
+// budgetsFile returns the path of the budgets file. Relative paths are resolved against the directory of the
+// config file, so that the suites and the preflight check find the same file.
+func budgetsFile(testConfig Config) string {
+	if filepath.IsAbs(testConfig.Budgets) || viper.ConfigFileUsed() == "" {
+		return testConfig.Budgets
+	}
+	return filepath.Join(filepath.Dir(viper.ConfigFileUsed()), testConfig.Budgets)
+}
+
+// readBudgets reads the budgets file configured as 'budgets'; there are no budgets if it is not configured.
+func readBudgets(testConfig Config) ([]Budget, error) {
+	if testConfig.Budgets == "" {
+		return nil, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(budgetsFile(testConfig))
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, ok := v.Get("budgets").([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s contains no list 'budgets'", v.ConfigFileUsed())
+	}
+	var budgets []Budget
+	for i, entry := range entries {
+		values, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("budget %d is not a map", i+1)
+		}
+		budget := Budget{Experiment: fmt.Sprint(values["experiment"])}
+		if values["experiment"] == nil || budget.Experiment == "" {
+			return nil, fmt.Errorf("budget %d has no experiment", i+1)
+		}
+		thresholds := []struct {
+			name      string
+			threshold *time.Duration
+		}{{"p95", &budget.P95}, {"mean", &budget.Mean}, {"max", &budget.Max}}
+		for _, t := range thresholds {
+			if values[t.name] == nil {
+				continue
+			}
+			*t.threshold, err = parseBudgetThreshold(values[t.name])
+			if err != nil {
+				return nil, fmt.Errorf("budget '%s' has an invalid %s: %s", budget.Experiment, t.name, err.Error())
+			}
+		}
+		budget.pattern = budgetPattern(budget.Experiment)
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+func loadBudgets(testConfig Config) []Budget {
+	budgets, err := readBudgets(testConfig)
+	if err != nil {
+		log.Fatalf("error loading budgets '%s': %s", testConfig.Budgets, err.Error())
+	}
+	return budgets
+}
+
+// parseBudgetThreshold accepts durations in seconds, like the timeouts of the config, or with a unit, e.g. "500ms".
+func parseBudgetThreshold(value interface{}) (time.Duration, error) {
+	var threshold time.Duration
+	switch v := value.(type) {
+	case int:
+		threshold = time.Duration(v) * time.Second
+	case float64:
+		threshold = time.Duration(v * float64(time.Second))
+	case string:
+		var err error
+		threshold, err = time.ParseDuration(v)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("'%v' is not a duration", value)
+	}
+	if threshold <= 0 {
+		return 0, fmt.Errorf("must be positive, is %v", threshold)
+	}
+	return threshold, nil
+}
+
+func budgetPattern(experiment string) *regexp.Regexp {
+	parts := strings.Split(experiment, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+func (b Budget) matches(key string) bool {
+	_, name, _ := strings.Cut(key, "::")
+	return b.pattern.MatchString(name) || b.pattern.MatchString(key)
+}
+
+// evaluateBudgets checks the request time of every experiment against the matching budgets. It records the outcome
+// in the report and returns the exceeded budgets by experiment.
+func evaluateBudgets(reporter *JsonReporter) map[string][]string {
+	var keys []string
+	for key := range reporter.Measurements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	failures := map[string][]string{}
+	for _, key := range keys {
+		requestTime, found := reporter.Measurements[key][results.RequestTimeMeasurement]
+		if !found {
+			continue
+		}
+		for _, budget := range reporter.budgets {
+			if !budget.matches(key) {
+				continue
+			}
+			for _, s := range budgetStatistics {
+				threshold := s.threshold(budget)
+				if threshold == 0 {
+					continue
+				}
+				outcome := results.BudgetOutcome{
+					Experiment: key,
+					Budget:     budget.Experiment,
+					Statistic:  s.name,
+					Threshold:  threshold.Seconds(),
+					Value:      s.statistic(requestTime),
+				}
+				outcome.Passed = outcome.Value <= outcome.Threshold
+				reporter.Budgets = append(reporter.Budgets, outcome)
+				if !outcome.Passed {
+					failures[key] = append(failures[key], fmt.Sprintf("%s of %.3fs exceeds the budget of %v (budget '%s')",
+						s.name, outcome.Value, threshold, budget.Experiment))
+				}
+			}
+		}
+	}
+	return failures
+}
+
+// failExceededBudgets fails the ReportAfterSuite node, and with it the suite, if an experiment exceeded a budget.
+func failExceededBudgets(failures map[string][]string) {
+	if len(failures) == 0 {
+		return
+	}
+	var keys []string
+	for key := range failures {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var message strings.Builder
+	message.WriteString("latency budgets exceeded:")
+	for _, key := range keys {
+		fmt.Fprintf(&message, "\n  %s:", key)
+		for _, failure := range failures[key] {
+			fmt.Fprintf(&message, "\n    %s", failure)
+		}
+	}
+	Fail(message.String())
+}
@@ -0,0 +1,182 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+func newTestBudget(experiment string, p95, mean, max time.Duration) Budget {
+	return Budget{Experiment: experiment, P95: p95, Mean: mean, Max: max, pattern: budgetPattern(experiment)}
+}
+
+func TestBudgetMatches(t *testing.T) {
+	tests := []struct {
+		experiment string
+		key        string
+		expected   bool
+	}{
+		{"GET /v3/roles::as admin", "roles::GET /v3/roles::as admin", true},
+		{"GET /v3/roles::as admin", "roles::GET /v3/roles::as admin with page size 500", false},
+		{"GET /v3/roles::as admin*", "roles::GET /v3/roles::as admin with page size 500", true},
+		{"GET /v3/roles::as regular user*", "roles::GET /v3/roles::as admin", false},
+		{"*::as admin", "roles::GET /v3/roles::as admin", true},
+		{"*", "roles::GET /v3/roles::as admin", true},
+		// with the test headline
+		{"roles::GET /v3/roles*", "roles::GET /v3/roles::as admin", true},
+		{"apps::GET /v3/roles*", "roles::GET /v3/roles::as admin", false},
+		{"roles::*", "roles::GET /v3/roles::as admin", true},
+		// characters of regular expressions are matched literally
+		{"GET /v3/roles?types=::as admin", "roles::GET /v3/roles?types=::as admin", true},
+		{"GET /v3/roles?types=::as admin", "roles::GET /v3/roless=::as admin", false},
+		{"GET /v3/roles.*", "roles::GET /v3/roles::as admin", false},
+	}
+	for _, test := range tests {
+		budget := newTestBudget(test.experiment, time.Second, 0, 0)
+		if actual := budget.matches(test.key); actual != test.expected {
+			t.Errorf("budget '%s' matches '%s' = %t, expected %t", test.experiment, test.key, actual, test.expected)
+		}
+	}
+}
+
+func TestParseBudgetThreshold(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected time.Duration
+	}{
+		{2, 2 * time.Second},
+		{1.5, 1500 * time.Millisecond},
+		{"500ms", 500 * time.Millisecond},
+		{"2s", 2 * time.Second},
+		{"1m", time.Minute},
+	}
+	for _, test := range tests {
+		actual, err := parseBudgetThreshold(test.value)
+		if err != nil || actual != test.expected {
+			t.Errorf("parseBudgetThreshold(%v) = %v, %v, expected %v", test.value, actual, err, test.expected)
+		}
+	}
+}
+
+func TestParseBudgetThresholdErrors(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{0, "must be positive"},
+		{-1, "must be positive"},
+		{-0.5, "must be positive"},
+		{"0s", "must be positive"},
+		{"-1s", "must be positive"},
+		{"2", "missing unit"},
+		{"fast", "invalid duration"},
+		{true, "is not a duration"},
+	}
+	for _, test := range tests {
+		_, err := parseBudgetThreshold(test.value)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("parseBudgetThreshold(%v) returned error %v, expected '%s'", test.value, err, test.expected)
+		}
+	}
+}
+
+func TestReadBudgets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budgets.yml")
+	content := `budgets:
+  - experiment: "GET /v3/roles::as regular user*"
+    p95: 2
+    mean: 1.5
+    max: 5s
+  - experiment: "GET /v3/apps::as admin"
+    mean: 500ms
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	budgets, err := readBudgets(Config{Budgets: path})
+	if err != nil {
+		t.Fatalf("readBudgets returned %v", err)
+	}
+	expected := []Budget{
+		newTestBudget("GET /v3/roles::as regular user*", 2*time.Second, 1500*time.Millisecond, 5*time.Second),
+		newTestBudget("GET /v3/apps::as admin", 0, 500*time.Millisecond, 0),
+	}
+	if !reflect.DeepEqual(budgets, expected) {
+		t.Errorf("readBudgets returned %+v, expected %+v", budgets, expected)
+	}
+}
+
+func TestReadBudgetsErrors(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"other: []\n", "contains no list 'budgets'"},
+		{"budgets:\n  - 2s\n", "budget 1 is not a map"},
+		{"budgets:\n  - p95: 2s\n", "budget 1 has no experiment"},
+		{"budgets:\n  - experiment: \"*\"\n    p95: 0\n", "budget '*' has an invalid p95: must be positive"},
+		{"budgets:\n  - experiment: \"*\"\n    max: soon\n", "budget '*' has an invalid max"},
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "budgets.yml")
+		if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := readBudgets(Config{Budgets: path})
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("readBudgets of %q returned error %v, expected '%s'", test.content, err, test.expected)
+		}
+	}
+}
+
+func TestReadBudgetsNotConfigured(t *testing.T) {
+	budgets, err := readBudgets(Config{})
+	if budgets != nil || err != nil {
+		t.Errorf("readBudgets without budgets returned %v, %v", budgets, err)
+	}
+}
+
+func TestEvaluateBudgets(t *testing.T) {
+	requestTime := func(p95, mean, max float64) map[string]Measurement {
+		return map[string]Measurement{results.RequestTimeMeasurement: {P95: p95, Average: mean, Largest: max}}
+	}
+	reporter := &JsonReporter{budgets: []Budget{
+		newTestBudget("GET /v3/roles::*", 2*time.Second, 0, 5*time.Second),
+		newTestBudget("roles::GET /v3/roles::as regular user", 0, 500*time.Millisecond, 0),
+	}}
+	reporter.Measurements = map[string]map[string]Measurement{
+		"roles::GET /v3/roles::as admin":        requestTime(1.5, 1, 3),
+		"roles::GET /v3/roles::as regular user": requestTime(2.5, 1, 6),
+		// experiments without request time are not checked
+		"roles::GET /v3/roles::without request time": {QueryCountMeasurement: {}},
+	}
+
+	failures := evaluateBudgets(reporter)
+
+	expectedFailures := map[string][]string{
+		"roles::GET /v3/roles::as regular user": {
+			"p95 of 2.500s exceeds the budget of 2s (budget 'GET /v3/roles::*')",
+			"max of 6.000s exceeds the budget of 5s (budget 'GET /v3/roles::*')",
+			"mean of 1.000s exceeds the budget of 500ms (budget 'roles::GET /v3/roles::as regular user')",
+		},
+	}
+	if !reflect.DeepEqual(failures, expectedFailures) {
+		t.Errorf("evaluateBudgets returned %v, expected %v", failures, expectedFailures)
+	}
+	expectedOutcomes := []results.BudgetOutcome{
+		{Experiment: "roles::GET /v3/roles::as admin", Budget: "GET /v3/roles::*", Statistic: "p95", Threshold: 2, Value: 1.5, Passed: true},
+		{Experiment: "roles::GET /v3/roles::as admin", Budget: "GET /v3/roles::*", Statistic: "max", Threshold: 5, Value: 3, Passed: true},
+		{Experiment: "roles::GET /v3/roles::as regular user", Budget: "GET /v3/roles::*", Statistic: "p95", Threshold: 2, Value: 2.5, Passed: false},
+		{Experiment: "roles::GET /v3/roles::as regular user", Budget: "GET /v3/roles::*", Statistic: "max", Threshold: 5, Value: 6, Passed: false},
+		{Experiment: "roles::GET /v3/roles::as regular user", Budget: "roles::GET /v3/roles::as regular user", Statistic: "mean", Threshold: 0.5, Value: 1, Passed: false},
+	}
+	if !reflect.DeepEqual(reporter.Budgets, expectedOutcomes) {
+		t.Errorf("evaluateBudgets recorded %+v, expected %+v", reporter.Budgets, expectedOutcomes)
+	}
+}
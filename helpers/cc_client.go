@@ -0,0 +1,587 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+// Exit codes returned by TimeCCRequestReturning; they mirror the ones of `cf curl --fail` so that existing
+// assertions on the exit code keep their meaning.
+const (
+	CCRequestSucceeded = 0
+	CCRequestFailed    = 1
+	CCRequestHTTPError = 22
+	CCRequestTimedOut  = 28
+)
+
+const tokenExpiryGracePeriod = 30 * time.Second
+
+// Names of the measurements recorded for the phases of a timed request.
+const (
+	DNSLookupPhase       = "dns lookup"
+	ConnectPhase         = "connection setup"
+	TLSHandshakePhase    = "tls handshake"
+	TimeToFirstBytePhase = "time to first byte"
+	BodyDownloadPhase    = "body download"
+)
+
+var RequestPhases = []string{DNSLookupPhase, ConnectPhase, TLSHandshakePhase, TimeToFirstBytePhase, BodyDownloadPhase}
+
+// CCClient sends requests to the Cloud Controller API without going through the cf CLI, so that measured
+// durations only contain the time spent on the request itself.
+type CCClient struct {
+	apiEndpoint string
+	httpClient  *http.Client
+
+	lock              sync.Mutex
+	tokenEndpoint     string
+	oauthClient       string
+	oauthClientSecret string
+	accessToken       string
+	refreshToken      string
+}
+
+type CCResponse struct {
+	StatusCode int
+	Status     string
+	Proto      string
+	Body       []byte
+	Timings    RequestTimings
+}
+
+// RequestTimings breaks the duration of a request down into its phases. TimeToFirstByte is measured from the
+// request being written until the first response byte arrives, i.e. it is the time spent in the Cloud Controller.
+type RequestTimings struct {
+	DNSLookup       time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	BodyDownload    time.Duration
+}
+
+func (timings RequestTimings) byPhase() map[string]time.Duration {
+	return map[string]time.Duration{
+		DNSLookupPhase:       timings.DNSLookup,
+		ConnectPhase:         timings.Connect,
+		TLSHandshakePhase:    timings.TLSHandshake,
+		TimeToFirstBytePhase: timings.TimeToFirstByte,
+		BodyDownloadPhase:    timings.BodyDownload,
+	}
+}
+
+type cfCLIConfig struct {
+	Target                string
+	SkipSSLValidation     bool `json:"SSLDisabled"`
+	AccessToken           string
+	RefreshToken          string
+	UAAEndpoint           string
+	AuthorizationEndpoint string
+	UAAOAuthClient        string
+	UAAOAuthClientSecret  string
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// the client of the most recent cf CLI session; workflowhelpers.AsUser creates a new $CF_HOME for every login
+var cfHomeClient *CCClient
+var cfHomeClientDir string
+var cfHomeClientLock sync.Mutex
+
+// NewCCClient creates an unauthenticated client for the API endpoint and SSL settings of the given config.
+func NewCCClient(testConfig Config) *CCClient {
+	return newCCClient(testConfig.GetApiEndpoint(), testConfig.GetSkipSSLValidation())
+}
+
+func newCCClient(apiEndpoint string, skipSslValidation bool) *CCClient {
+	return &CCClient{
+		apiEndpoint: strings.TrimSuffix(apiEndpoint, "/"),
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: skipSslValidation},
+				// every sample opens its own connection, as it did when each request was a separate cf CLI process
+				DisableKeepAlives: true,
+			},
+		},
+		oauthClient: "cf",
+	}
+}
+
+// NewCCClientFromCFHome creates a client that reuses the target and tokens of the cf CLI session in $CF_HOME,
+// i.e. the session opened by workflowhelpers.AsUser.
+func NewCCClientFromCFHome() (*CCClient, error) {
+	cliConfig, err := readCFCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cliConfig.Target == "" || cliConfig.AccessToken == "" {
+		return nil, errors.New("cf CLI is not logged in")
+	}
+
+	client := newCCClient(cliConfig.Target, cliConfig.SkipSSLValidation)
+	client.accessToken = strings.TrimPrefix(strings.TrimPrefix(cliConfig.AccessToken, "bearer "), "Bearer ")
+	client.refreshToken = cliConfig.RefreshToken
+	client.tokenEndpoint = cliConfig.UAAEndpoint
+	if client.tokenEndpoint == "" {
+		client.tokenEndpoint = cliConfig.AuthorizationEndpoint
+	}
+	if cliConfig.UAAOAuthClient != "" {
+		client.oauthClient = cliConfig.UAAOAuthClient
+		client.oauthClientSecret = cliConfig.UAAOAuthClientSecret
+	}
+	return client, nil
+}
+
+// NewAdminCCClient creates a client authenticated with the admin user (or admin client) of the given config.
+func NewAdminCCClient(testConfig Config) (*CCClient, error) {
+	client := NewCCClient(testConfig)
+	var err error
+	if testConfig.GetAdminClient() != "" {
+		err = client.AuthenticateClient(testConfig.GetAdminClient(), testConfig.GetAdminClientSecret())
+	} else {
+		err = client.Authenticate(testConfig.GetAdminUser(), testConfig.GetAdminPassword())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// Authenticate fetches a token for the given user via the password grant.
+func (c *CCClient) Authenticate(username, password string) error {
+	return c.requestToken(url.Values{
+		"grant_type": {"password"},
+		"username":   {username},
+		"password":   {password},
+	})
+}
+
+// AuthenticateClient fetches a token for the given UAA client via the client credentials grant.
+func (c *CCClient) AuthenticateClient(client, clientSecret string) error {
+	c.lock.Lock()
+	c.oauthClient = client
+	c.oauthClientSecret = clientSecret
+	c.lock.Unlock()
+	return c.requestToken(url.Values{"grant_type": {"client_credentials"}})
+}
+
+// Do sends a request to the given API path; a response with an error status is not considered an error.
+func (c *CCClient) Do(ctx context.Context, method string, path string, body []byte, headers http.Header) (*CCResponse, error) {
+	token, err := c.validAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	tracer := &requestTracer{}
+	request, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, tracer.clientTrace()), method, c.apiEndpoint+path, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	if body != nil && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "bearer "+token)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CCResponse{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Proto:      response.Proto,
+		Body:       responseBody,
+		Timings:    tracer.finish(),
+	}, nil
+}
+
+// TimeCCRequest is the native counterpart of TimeCFCurl: it accepts the same arguments and fails if the request
+// does not succeed. In load mode, failed requests are counted for the error rate instead.
+func TimeCCRequest(timeout time.Duration, curlArguments ...string) {
+	exitCode, _ := TimeCCRequestReturning(timeout, curlArguments...)
+	if activeLoadRun.Load() == nil {
+		Expect(exitCode).To(Equal(CCRequestSucceeded))
+	}
+}
+
+// TimeCCRequestReturning is the native counterpart of TimeCFCurlReturning. In addition to what CCRequestReturning
+// does, it records the duration of each request phase in the experiment of the current spec, and counts the queries
+// of the request with testConfig.QueryStatistics.
+func TimeCCRequestReturning(timeout time.Duration, curlArguments ...string) (int, []byte) {
+	var exitCode int
+	var output []byte
+	var response *CCResponse
+	countQueries(func() {
+		exitCode, output, response = ccRequest(timeout, curlArguments...)
+	})
+	if response != nil {
+		recordRequestPhases(response.Timings)
+	}
+	if run := activeLoadRun.Load(); run != nil {
+		run.requests.Add(1)
+		if exitCode != CCRequestSucceeded {
+			run.failures.Add(1)
+		}
+	}
+	return exitCode, output
+}
+
+// CCRequestReturning sends a request that is not part of a measurement, e.g. to prepare test data. It uses the
+// session of the user currently logged in via workflowhelpers.AsUser and understands the `cf curl` arguments -X,
+// -H and -d. The returned output consists of the status line followed by the response body, so that checks
+// written against `cf curl -v` output keep working.
+func CCRequestReturning(timeout time.Duration, curlArguments ...string) (int, []byte) {
+	exitCode, output, _ := ccRequest(timeout, curlArguments...)
+	return exitCode, output
+}
+
+func ccRequest(timeout time.Duration, curlArguments ...string) (int, []byte, *CCResponse) {
+	method, path, body, headers, err := parseCurlArguments(curlArguments)
+	Expect(err).NotTo(HaveOccurred())
+
+	client, err := ccClientForCFHome()
+	Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := client.Do(ctx, method, path, body, headers)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return CCRequestTimedOut, []byte(err.Error()), nil
+		}
+		return CCRequestFailed, []byte(err.Error()), nil
+	}
+
+	output := []byte(fmt.Sprintf("%s %s\n", response.Proto, response.Status))
+	output = append(output, response.Body...)
+
+	if response.StatusCode >= 400 {
+		return CCRequestHTTPError, output, response
+	}
+	return CCRequestSucceeded, output, response
+}
+
+// recordRequestPhases adds the phase durations to the experiment most recently added as report entry of the
+// current spec; requests sent before an experiment exists are not recorded.
+func recordRequestPhases(timings RequestTimings) {
+	experiment := currentExperiment()
+	if experiment == nil {
+		return
+	}
+	durations := timings.byPhase()
+	for _, phase := range RequestPhases {
+		experiment.RecordDuration(phase, durations[phase])
+	}
+}
+
+func currentExperiment() *gmeasure.Experiment {
+	entries := CurrentSpecReport().ReportEntries
+	for i := len(entries) - 1; i >= 0; i-- {
+		if experiment, ok := entries[i].Value.GetRawValue().(*gmeasure.Experiment); ok {
+			return experiment
+		}
+	}
+	return nil
+}
+
+// requestTracer collects the phase timings of a single request; the callbacks of httptrace may be invoked
+// concurrently, e.g. when dialing several addresses.
+type requestTracer struct {
+	lock                                                      sync.Mutex
+	timings                                                   RequestTimings
+	dnsStart, connectStart, tlsStart, wroteRequest, firstByte time.Time
+}
+
+func (t *requestTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.measure(&t.timings.DNSLookup, &t.dnsStart) },
+		ConnectStart:      func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { t.measure(&t.timings.Connect, &t.connectStart) },
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.measure(&t.timings.TLSHandshake, &t.tlsStart) },
+		WroteRequest:      func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() {
+			t.mark(&t.firstByte)
+			t.measure(&t.timings.TimeToFirstByte, &t.wroteRequest)
+		},
+	}
+}
+
+func (t *requestTracer) mark(timestamp *time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	*timestamp = time.Now()
+}
+
+func (t *requestTracer) measure(duration *time.Duration, start *time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	*duration = time.Since(*start)
+}
+
+// finish is called once the response body has been read completely.
+func (t *requestTracer) finish() RequestTimings {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.firstByte.IsZero() {
+		t.timings.BodyDownload = time.Since(t.firstByte)
+	}
+	return t.timings
+}
+
+func ccClientForCFHome() (*CCClient, error) {
+	cfHome := cfHomeDir()
+
+	cfHomeClientLock.Lock()
+	defer cfHomeClientLock.Unlock()
+
+	if cfHomeClient != nil && cfHomeClientDir == cfHome {
+		return cfHomeClient, nil
+	}
+	client, err := NewCCClientFromCFHome()
+	if err != nil {
+		return nil, err
+	}
+	cfHomeClient, cfHomeClientDir = client, cfHome
+	return client, nil
+}
+
+func cfHomeDir() string {
+	cfHome := os.Getenv("CF_HOME")
+	if cfHome == "" {
+		cfHome, _ = os.UserHomeDir()
+	}
+	return cfHome
+}
+
+func readCFCLIConfig() (*cfCLIConfig, error) {
+	contents, err := os.ReadFile(filepath.Join(cfHomeDir(), ".cf", "config.json"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cf CLI config: %w", err)
+	}
+	var cliConfig cfCLIConfig
+	err = json.Unmarshal(contents, &cliConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse cf CLI config: %w", err)
+	}
+	return &cliConfig, nil
+}
+
+func parseCurlArguments(curlArguments []string) (method string, path string, body []byte, headers http.Header, err error) {
+	headers = http.Header{}
+	for i := 0; i < len(curlArguments); i++ {
+		argument := curlArguments[i]
+		switch argument {
+		case "-X", "-H", "-d":
+			if i+1 >= len(curlArguments) {
+				return "", "", nil, nil, fmt.Errorf("missing value for '%s'", argument)
+			}
+			i++
+			value := curlArguments[i]
+			switch argument {
+			case "-X":
+				method = value
+			case "-H":
+				name, headerValue, found := strings.Cut(value, ":")
+				if !found {
+					return "", "", nil, nil, fmt.Errorf("invalid header '%s'", value)
+				}
+				headers.Add(strings.TrimSpace(name), strings.TrimSpace(headerValue))
+			case "-d":
+				if strings.HasPrefix(value, "@") {
+					body, err = os.ReadFile(strings.TrimPrefix(value, "@"))
+					if err != nil {
+						return "", "", nil, nil, err
+					}
+				} else {
+					body = []byte(value)
+				}
+			}
+		case "--fail", "-f", "-v", "-i":
+			// implied by the native client
+		default:
+			if strings.HasPrefix(argument, "-") {
+				return "", "", nil, nil, fmt.Errorf("unsupported argument '%s'", argument)
+			}
+			path = argument
+		}
+	}
+
+	if path == "" {
+		return "", "", nil, nil, errors.New("missing path")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if method == "" {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+	return method, path, body, headers, nil
+}
+
+func (c *CCClient) validAccessToken() (string, error) {
+	c.lock.Lock()
+	token := c.accessToken
+	refreshToken := c.refreshToken
+	c.lock.Unlock()
+
+	if token == "" || !tokenExpiresSoon(token) || refreshToken == "" {
+		return token, nil
+	}
+
+	err := c.requestToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.accessToken, nil
+}
+
+func (c *CCClient) requestToken(form url.Values) error {
+	tokenEndpoint, err := c.getTokenEndpoint()
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	oauthClient, oauthClientSecret := c.oauthClient, c.oauthClientSecret
+	c.lock.Unlock()
+
+	request, err := http.NewRequest(http.MethodPost, tokenEndpoint+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(oauthClient, oauthClientSecret)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request failed with %s: %s", response.Status, responseBody)
+	}
+
+	var token tokenResponse
+	err = json.Unmarshal(responseBody, &token)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.accessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		c.refreshToken = token.RefreshToken
+	}
+	return nil
+}
+
+func (c *CCClient) getTokenEndpoint() (string, error) {
+	c.lock.Lock()
+	tokenEndpoint := c.tokenEndpoint
+	c.lock.Unlock()
+	if tokenEndpoint != "" {
+		return tokenEndpoint, nil
+	}
+
+	var root struct {
+		Links struct {
+			Login struct {
+				Href string `json:"href"`
+			} `json:"login"`
+		} `json:"links"`
+	}
+	response, err := c.httpClient.Get(c.apiEndpoint + "/")
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	err = json.NewDecoder(response.Body).Decode(&root)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse API root: %w", err)
+	}
+	if root.Links.Login.Href == "" {
+		return "", errors.New("API root does not contain a login link")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.tokenEndpoint = strings.TrimSuffix(root.Links.Login.Href, "/")
+	return c.tokenEndpoint, nil
+}
+
+func tokenExpiresSoon(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return false
+	}
+	return time.Until(time.Unix(claims.Exp, 0)) < tokenExpiryGracePeriod
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -21,6 +22,17 @@ type Users struct {
 	Existing User
 }
 
+// Load configures the optional concurrent load mode of the experiments. The mode is active if at least one of
+// Workers and Rate is set; OpenLoop requires a Rate.
+type Load struct {
+	Workers  int
+	Rate     float64
+	Duration time.Duration
+	OpenLoop bool `mapstructure:"open_loop"`
+}
+
+func (load Load) Enabled() bool { return load.Workers > 0 || load.Rate > 0 }
+
 const PsqlDb string = "postgres"
 const MysqlDb string = "mysql"
 
@@ -41,9 +53,25 @@ type Config struct {
 	UaadbConnection     string `mapstructure:"uaadb_connection"`
 	ResultsFolder       string `mapstructure:"results_folder"`
 	TestResourcePrefix  string `mapstructure:"test_resource_prefix"`
+	Dataset             string
+	// factor applied to the dataset parameters given as plain numbers, e.g. 0.01 for a quick run
+	Scale float64
+	// dataset parameters per suite, keyed by the suite directory, e.g. suites.domains.orgs; they are not scaled
+	Suites          map[string]map[string]interface{}
+	Snapshots       bool
+	ExplainPlans    bool `mapstructure:"explain_plans"`
+	QueryStatistics bool `mapstructure:"query_statistics"`
+	Load            Load
+	Safety          Safety
+	Metrics         Metrics
+	// file with the latency budgets of the experiments, relative to the config file
+	Budgets string
 }
 
+// NewConfig returns the default config. It also defines the flags overriding the config keys (see LoadConfig), so
+// that they are known when go test or Ginkgo parse the command line.
 func NewConfig() Config {
+	registerConfigFlags()
 	return Config{
 		LargePageSize:       500,
 		LargeElementsFilter: 100,
@@ -93,10 +121,24 @@ func ConfigureJsonReporter(testConfig *Config, testSuiteName string, testHeadlin
 	}
 
 	timestamp := time.Now().Unix()
-	return NewJsonReporter(fmt.Sprintf("%s/%s-test-results-%d.json", resultsFolder, testSuiteName, timestamp), testHeadlineName, testConfig.CfDeploymentVersion, testConfig.CapiVersion, timestamp, testSuiteName, testConfig.DatabaseType)
+	reporter := NewJsonReporter(fmt.Sprintf("%s/%s-test-results-%d.json", resultsFolder, testSuiteName, timestamp), testHeadlineName, testConfig.CfDeploymentVersion, testConfig.CapiVersion, timestamp, testSuiteName, ccdbSchemaVersion)
+	reporter.DatabaseType = testConfig.DatabaseType
+	reporter.Dataset = testConfig.Dataset
+	reporter.Run = collectRunMetadata(*testConfig, test_version)
+	reporter.metrics = testConfig.Metrics
+	reporter.budgets = loadBudgets(*testConfig)
+	if loadedDataset != nil {
+		reporter.Scale = testConfig.Scale
+		reporter.Parameters = loadedDataset.Parameters()
+	}
+	return reporter
 }
 
-func LoadConfig(testConfig *Config) {
+// ReadConfig reads the config file and applies the overrides, without validating the result. In order of
+// precedence, config keys are read from flags, e.g. --users.admin.password, from CF_PERF_* environment variables,
+// e.g. CF_PERF_USERS_ADMIN_PASSWORD, from the config file, and from the defaults. The config file is optional if
+// all required keys are overridden.
+func ReadConfig(testConfig *Config) {
 	viper.SetConfigName("config")
 	viper.AddConfigPath("../../")
 	viper.AddConfigPath("$HOME/.cf-performance-tests")
@@ -105,10 +147,15 @@ func LoadConfig(testConfig *Config) {
 	viper.SetDefault("database_type", PsqlDb)
 	viper.SetDefault("basic_timeout", 60)
 	viper.SetDefault("long_timeout", 180)
+	viper.SetDefault("dataset", "default")
+	viper.SetDefault("scale", 1)
 	err := viper.ReadInConfig()
-	if err != nil {
+	if _, notFound := err.(viper.ConfigFileNotFoundError); notFound {
+		log.Printf("No config file found, using flags and environment variables only")
+	} else if err != nil {
 		log.Fatalf("error loading config: %s", err.Error())
 	}
+	applyConfigOverrides()
 	err = viper.Unmarshal(testConfig)
 	if err != nil {
 		log.Fatalf("error parsing config: %s", err.Error())
@@ -116,8 +163,20 @@ func LoadConfig(testConfig *Config) {
 
 	testConfig.BasicTimeout *= time.Second
 	testConfig.LongTimeout *= time.Second
+	testConfig.Load.Duration *= time.Second
+	// the suites require at least large_page_size or large_elements_filter resources of the dataset, so these
+	// scale along with it; scaling does not push a valid page size above the maximum
+	if testConfig.Scale > 0 && testConfig.Scale != 1 {
+		testConfig.LargePageSize = min(scaled(testConfig.LargePageSize, testConfig.Scale), max(testConfig.LargePageSize, maxPageSize))
+		testConfig.LargeElementsFilter = scaled(testConfig.LargeElementsFilter, testConfig.Scale)
+	}
+	printConfigIfRequested(*testConfig)
+}
 
-	if testConfig.DatabaseType != PsqlDb && testConfig.DatabaseType != MysqlDb {
-		log.Fatalf("'database_type' parameter must be one of '%s' or '%s'", PsqlDb, MysqlDb)
+// LoadConfig reads the config with ReadConfig and stops with all problems found by ValidateConfig.
+func LoadConfig(testConfig *Config) {
+	ReadConfig(testConfig)
+	if problems := ValidateConfig(*testConfig); len(problems) > 0 {
+		log.Fatalf("invalid config:\n  %s", strings.Join(problems, "\n  "))
 	}
 }
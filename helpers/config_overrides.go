@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// prefix of the environment variables overriding config keys, e.g. CF_PERF_USERS_ADMIN_PASSWORD for
+// users.admin.password
+const configEnvPrefix = "CF_PERF_"
+
+const printConfigFlag = "print-config"
+
+// configKey is a key of the config file, with the type of its Config field.
+type configKey struct {
+	name string
+	kind reflect.Type
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// configKeys returns the keys of all Config fields, e.g. "users.admin.username", in the naming used by viper:
+// the mapstructure tag or the lowercase field name. Maps like the per-suite dataset parameters can only be set in
+// the config file.
+func configKeys(t reflect.Type, prefix string) []configKey {
+	var keys []configKey
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		switch field.Type.Kind() {
+		case reflect.Map:
+		case reflect.Struct:
+			keys = append(keys, configKeys(field.Type, prefix+name+".")...)
+		default:
+			keys = append(keys, configKey{name: prefix + name, kind: field.Type})
+		}
+	}
+	return keys
+}
+
+// registerConfigFlags defines a flag for every config key and --print-config, once, on the command line flag set,
+// so that they are parsed together with the flags of go test and Ginkgo.
+func registerConfigFlags() {
+	if flag.Lookup(printConfigFlag) != nil {
+		return
+	}
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		flag.String(key.name, "", fmt.Sprintf("overrides '%s' of the config file", key.name))
+	}
+	flag.Bool(printConfigFlag, false, "print the effective configuration with secrets masked before running the tests")
+}
+
+func configEnvVar(key string) string {
+	return configEnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// applyConfigOverrides sets the config keys given as environment variables, and then those given as flags, so
+// that flags take precedence over environment variables, which take precedence over the config file.
+func applyConfigOverrides() {
+	keys := configKeys(reflect.TypeOf(Config{}), "")
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(configEnvVar(key.name)); ok {
+			setConfigOverride(key, value, configEnvVar(key.name))
+		}
+	}
+	for _, key := range keys {
+		if f := flag.Lookup(key.name); f != nil && isFlagSet(key.name) {
+			setConfigOverride(key, f.Value.String(), "--"+key.name)
+		}
+	}
+}
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// setConfigOverride sets the key to the value; other types than durations are converted when unmarshalling.
+// Durations are given in seconds, like in the config file, or with a unit, e.g. "3m".
+func setConfigOverride(key configKey, value string, source string) {
+	if key.kind != durationType {
+		viper.Set(key.name, value)
+		return
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		viper.Set(key.name, seconds)
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("error parsing %s: %s", source, err.Error())
+	}
+	viper.Set(key.name, int(duration/time.Second))
+}
+
+// printConfigIfRequested prints the effective configuration, if --print-config is set. It does not exit, as the
+// config is loaded within go test; combine it with --ginkgo.dry-run to only print it.
+func printConfigIfRequested(testConfig Config) {
+	f := flag.Lookup(printConfigFlag)
+	if f == nil || f.Value.String() != "true" {
+		return
+	}
+	values := map[string]string{}
+	collectConfigValues(reflect.ValueOf(testConfig), "", values)
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s: %s\n", key, values[key])
+	}
+}
+
+func collectConfigValues(v reflect.Value, prefix string, values map[string]string) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if field.Type.Kind() == reflect.Struct {
+			collectConfigValues(v.Field(i), prefix+name+".", values)
+			continue
+		}
+		value := fmt.Sprint(v.Field(i).Interface())
+		if isSecretConfigKey(name) && value != "" {
+			value = "********"
+		} else if u, err := url.Parse(value); err == nil && u.User != nil {
+			value = u.Redacted()
+		}
+		values[prefix+name] = value
+	}
+}
+
+// isSecretConfigKey returns true for passwords, client secrets and the database connection strings, which
+// usually contain credentials.
+func isSecretConfigKey(name string) bool {
+	return strings.Contains(name, "password") || strings.Contains(name, "secret") || strings.HasSuffix(name, "_connection")
+}
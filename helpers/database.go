@@ -1,16 +1,11 @@
 package helpers
 
 import (
-	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"path"
-	"runtime"
-	"strings"
-	"text/template"
+	"sort"
 	"time"
 
 	_ "github.com/jackc/pgx/v4"
@@ -31,219 +26,182 @@ func OpenDbConnections(testConfig Config) (ccdb, uaadb *sql.DB, ctx context.Cont
 
 	ccdb, err := sql.Open(driverName, testConfig.CcdbConnection)
 	checkError(err)
+	ccdbConnection = ccdb
+
+	ctx = context.Background()
+	ccdbSchemaVersion, err = readSchemaVersion(ccdb, ctx)
+	if err != nil {
+		log.Printf("Cannot read the CCDB schema version: %s", err.Error())
+	} else {
+		log.Printf("CCDB schema version: %s", ccdbSchemaVersion)
+	}
+	err = ccdb.QueryRowContext(ctx, "SELECT VERSION()").Scan(&ccdbServerVersion)
+	if err != nil {
+		log.Printf("Cannot read the CCDB server version: %s", err.Error())
+	}
 
 	if testConfig.UaadbConnection != "" {
 		uaadb, err = sql.Open(driverName, testConfig.UaadbConnection)
 		checkError(err)
 	}
 
-	ctx = context.Background()
 	return
 }
 
-func evaluateTemplate(templ string, testConfig Config) string {
-	type StoredProceduresSQLTemplate struct {
-		Prefix string
-	}
-
-	tmpl, err := template.New("sql_functions").Parse(templ)
-	if err != nil {
-		log.Fatal(err)
+func GetRandomFunction(testConfig Config) string {
+	if testConfig.DatabaseType == MysqlDb {
+		return "RAND()"
+	} else {
+		return "random()"
 	}
+}
 
-	templateResult := new(bytes.Buffer)
-	err = tmpl.Execute(templateResult, StoredProceduresSQLTemplate{testConfig.GetNamePrefix()})
-	if err != nil {
-		log.Fatal(err)
-	}
+// cleanupDelete deletes the rows of table matching the condition, in which %[1]s is replaced with the name pattern
+// of test resources. If the condition refers to rows of another table, the parent, the delete runs before the
+// rows of the parent are deleted.
+type cleanupDelete struct {
+	table     string
+	condition string
+	parent    string
+}
 
-	return templateResult.String()
+// deletes of resources not created by the Seeder, ordered by tablesInDeleteOrder when running them
+var cleanupDeletes = []cleanupDelete{
+	{"route_mappings", "route_guid IN (SELECT guid FROM routes WHERE host LIKE '%[1]s')", "routes"},
+	{"routes", "host LIKE '%[1]s'", ""},
+	{"routes", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"domain_annotations", "resource_guid IN (SELECT guid FROM domains WHERE name LIKE '%[1]s')", "domains"},
+	{"domains", "name LIKE '%[1]s'", ""},
+	{"service_bindings", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"buildpack_lifecycle_data", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"app_labels", "resource_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"processes", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"packages", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"builds", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"droplets", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"revisions", "app_guid IN (SELECT guid FROM apps WHERE name LIKE '%[1]s')", "apps"},
+	{"apps", "name LIKE '%[1]s'", ""},
+	{"service_keys", "name LIKE '%[1]s'", ""},
+	{"service_bindings", "service_instance_guid IN (SELECT guid FROM service_instances WHERE name LIKE '%[1]s')", "service_instances"},
+	{"service_instances", "name LIKE '%[1]s'", ""},
+	{"security_groups_spaces", "security_group_id IN (SELECT id FROM security_groups WHERE name LIKE '%[1]s')", "security_groups"},
+	{"security_groups_spaces", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"security_groups", "name LIKE '%[1]s'", ""},
+	{"spaces_managers", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"spaces_developers", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"spaces_supporters", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"spaces_auditors", "space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"organizations_managers", "organization_id IN (SELECT id FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"organizations_billing_managers", "organization_id IN (SELECT id FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"organizations_auditors", "organization_id IN (SELECT id FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"organizations_users", "organization_id IN (SELECT id FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"users", "default_space_id IN (SELECT id FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"space_labels", "resource_guid IN (SELECT guid FROM spaces WHERE name LIKE '%[1]s')", "spaces"},
+	{"spaces", "name LIKE '%[1]s'", ""},
+	{"service_plan_visibilities", "organization_id IN (SELECT id FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"organizations_isolation_segments", "organization_guid IN (SELECT guid FROM organizations WHERE name LIKE '%[1]s')", "organizations"},
+	{"organizations", "name LIKE '%[1]s'", ""},
+	{"quota_definitions", "name LIKE '%[1]s'", ""},
+	{"isolation_segment_annotations", "resource_guid IN (SELECT guid FROM isolation_segments WHERE name LIKE '%[1]s')", "isolation_segments"},
+	{"isolation_segments", "name LIKE '%[1]s'", ""},
+	{"events", "actee_name LIKE '%[1]s'", ""},
+	{"events", "actee LIKE '%[1]s'", ""},
+	{"service_plan_visibilities", "service_plan_id IN (SELECT id FROM service_plans WHERE name LIKE '%[1]s')", "service_plans"},
+	{"service_plans", "name LIKE '%[1]s'", ""},
+	{"services", "label LIKE '%[1]s'", ""},
+	{"service_brokers", "name LIKE '%[1]s'", ""},
 }
 
-func ImportStoredProcedures(ccdb *sql.DB, ctx context.Context, testConfig Config) {
-	_, currentDir, _, ok := runtime.Caller(0)
-	if !ok {
-		log.Fatal("Failed to retrieve current file location")
-	}
+// apps and their current droplets reference each other; the reference of the apps is removed before deleting
+// any rows, so that it is not considered when ordering the deletes
+const cleanupAppDropletsStatement = "UPDATE apps SET droplet_guid = NULL WHERE name LIKE '%s'"
 
-	if testConfig.DatabaseType == PsqlDb {
-		sqlFunctionsTemplate, err := ioutil.ReadFile(path.Join(path.Dir(currentDir), "../scripts/pgsql_functions.tmpl.sql"))
-		if err != nil {
-			log.Fatal(err)
-		}
+// the foreign keys cleared before deleting, as "table.column"
+var cleanupIgnoredForeignKeys = []string{"apps.droplet_guid"}
 
-		ExecuteStatement(ccdb, ctx, evaluateTemplate(string(sqlFunctionsTemplate), testConfig))
+// orderCleanupDeletes returns the cleanupDeletes ordered by the foreign keys of the CCDB, so that rows are deleted
+// before the rows they reference. Deletes of the same table keep their order.
+func orderCleanupDeletes(ccdb *sql.DB, ctx context.Context, testConfig Config) []cleanupDelete {
+	var references []tableReference
+	for _, cleanup := range cleanupDeletes {
+		references = append(references, tableReference{child: cleanup.table, parent: cleanup.parent})
 	}
-
-	if testConfig.DatabaseType == MysqlDb {
-		mysqlDir := path.Join(path.Dir(currentDir), "../scripts/mysql/")
-		mysqlDirFiles, err := ioutil.ReadDir(mysqlDir)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		for _, mysqlFile := range mysqlDirFiles {
-			log.Printf("Reading MySQL stored procedure from file '%s'...", mysqlFile.Name())
-			sqlTemplate, err := ioutil.ReadFile(path.Join(mysqlDir, mysqlFile.Name()))
-			if err != nil {
-				log.Fatal(err)
-			}
-			procedureName := strings.Split(mysqlFile.Name(), ".")[0]
-			ExecuteStatement(ccdb, ctx, fmt.Sprintf("DROP FUNCTION IF EXISTS %s", procedureName))
-			ExecuteStatement(ccdb, ctx, fmt.Sprintf("DROP PROCEDURE IF EXISTS %s", procedureName))
-			ExecuteStatement(ccdb, ctx, evaluateTemplate(string(sqlTemplate), testConfig))
-		}
+	position := map[string]int{}
+	for i, table := range tablesInDeleteOrder(ccdb, ctx, testConfig, references, cleanupIgnoredForeignKeys) {
+		position[table] = i
 	}
-}
 
-func GetRandomFunction(testConfig Config) string {
-	if testConfig.DatabaseType == MysqlDb {
-		return "RAND()"
-	} else {
-		return "random()"
+	var ordered []cleanupDelete
+	for _, cleanup := range cleanupDeletes {
+		if _, ok := position[cleanup.table]; !ok {
+			log.Printf("Skipping cleanup of '%s': table not found", cleanup.table)
+			continue
+		}
+		ordered = append(ordered, cleanup)
 	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return position[ordered[i].table] < position[ordered[j].table]
+	})
+	return ordered
 }
 
 func CleanupTestData(ccdb, uaadb *sql.DB, ctx context.Context, testConfig Config) {
-	deleteStatementsPostgres := []string{
-		"DELETE FROM route_mappings USING routes WHERE routes.guid = route_mappings.route_guid AND routes.host LIKE '%s'",
-		"DELETE FROM service_bindings USING apps WHERE apps.guid = service_bindings.app_guid AND apps.name LIKE '%s'",
-		"DELETE FROM routes WHERE host LIKE '%s'",
-		"DELETE FROM domain_annotations USING domains WHERE domain_annotations.resource_guid = domains.guid AND domains.name LIKE '%s'",
-		"DELETE FROM domains WHERE name LIKE '%s'",
-		"DELETE FROM processes USING apps WHERE apps.guid = processes.app_guid AND apps.name LIKE '%s'",
-		"DELETE FROM packages USING apps WHERE apps.guid = packages.app_guid AND apps.name LIKE '%s'",
-		"DELETE FROM builds USING apps WHERE apps.guid = builds.app_guid AND apps.name LIKE '%s'",
-		"UPDATE apps SET droplet_guid = NULL WHERE name LIKE '%s'",
-		"DELETE FROM droplets USING apps WHERE apps.guid = droplets.app_guid AND apps.name LIKE '%s'",
-		"DELETE FROM revisions USING apps WHERE apps.guid = revisions.app_guid AND apps.name LIKE '%s'",
-		"DELETE FROM apps WHERE name LIKE '%s'",
-		"DELETE FROM service_keys WHERE name LIKE '%s'",
-		"DELETE FROM service_bindings USING service_instances WHERE service_instances.guid = service_bindings.service_instance_guid AND service_instances.name LIKE '%s'",
-		"DELETE FROM service_instances WHERE name LIKE '%s'",
-		"DELETE FROM security_groups_spaces USING security_groups WHERE security_groups_spaces.security_group_id = security_groups.id AND security_groups.name LIKE '%s'",
-		"DELETE FROM security_groups_spaces USING spaces WHERE security_groups_spaces.space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM security_groups WHERE name LIKE '%s'",
-		"DELETE FROM spaces_managers USING spaces WHERE spaces_managers.space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM spaces_developers USING spaces WHERE spaces_developers.space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM spaces_supporters USING spaces WHERE spaces_supporters.space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM spaces_auditors USING spaces WHERE spaces_auditors.space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM organizations_managers USING organizations WHERE organizations_managers.organization_id = organizations.id AND organizations.name LIKE '%s'",
-		"DELETE FROM organizations_billing_managers USING organizations WHERE organizations_billing_managers.organization_id = organizations.id AND organizations.name LIKE '%s'",
-		"DELETE FROM organizations_auditors USING organizations WHERE organizations_auditors.organization_id = organizations.id AND organizations.name LIKE '%s'",
-		"DELETE FROM organizations_users USING organizations WHERE organizations_users.organization_id = organizations.id AND organizations.name LIKE '%s'",
-		"DELETE FROM users USING spaces WHERE users.default_space_id = spaces.id AND spaces.name LIKE '%s'",
-		"DELETE FROM space_labels USING spaces WHERE space_labels.resource_guid = spaces.guid AND spaces.name LIKE '%s'",
-		"DELETE FROM spaces WHERE name LIKE '%s'",
-		"DELETE FROM service_plan_visibilities USING organizations WHERE service_plan_visibilities.organization_id = organizations.id AND organizations.name LIKE '%s'",
-		"DELETE FROM organizations_isolation_segments USING organizations WHERE organizations_isolation_segments.organization_guid = organizations.guid AND organizations.name LIKE '%s'",
-		"DELETE FROM organizations WHERE name LIKE '%s'",
-		"DELETE FROM quota_definitions WHERE name LIKE '%s'",
-		"DELETE FROM isolation_segment_annotations USING isolation_segments WHERE isolation_segment_annotations.resource_guid = isolation_segments.guid AND isolation_segments.name LIKE '%s'",
-		"DELETE FROM isolation_segments WHERE name LIKE '%s'",
-		"DELETE FROM events WHERE actee_name LIKE '%s'",
-		"DELETE FROM events WHERE actee LIKE '%s'",
-		"DELETE FROM service_plan_visibilities USING service_plans WHERE service_plans.id = service_plan_visibilities.service_plan_id AND service_plans.name LIKE '%s'",
-		"DELETE FROM service_plans WHERE name LIKE '%s'",
-		"DELETE FROM services WHERE label LIKE '%s'",
-		"DELETE FROM service_brokers WHERE name LIKE '%s'",
-	}
-	deleteStatementsMySql := []string{
-		"DELETE FROM r_m USING route_mappings r_m, routes r WHERE r.guid = r_m.route_guid AND r.host LIKE '%s'",
-		"DELETE FROM r USING routes r, spaces s WHERE s.id = r.space_id AND s.name LIKE '%s'",
-		"DELETE FROM d_a USING domain_annotations d_a, domains d WHERE d_a.resource_guid = d.guid AND d.name LIKE '%s'",
-		"DELETE FROM domains WHERE name LIKE '%s'",
-		"DELETE FROM p USING processes p, apps a WHERE a.guid = p.app_guid AND a.name LIKE '%s'",
-		"DELETE FROM p USING packages p, apps a WHERE a.guid = p.app_guid AND a.name LIKE '%s'",
-		"DELETE FROM b USING builds b, apps a WHERE a.guid = b.app_guid AND a.name LIKE '%s'",
-		"UPDATE apps SET droplet_guid = NULL WHERE name LIKE '%s'",
-		"DELETE FROM d USING droplets d, apps a WHERE a.guid = d.app_guid AND a.name LIKE '%s'",
-		"DELETE FROM r USING revisions r, apps a WHERE a.guid = r.app_guid AND a.name LIKE '%s'",
-		"DELETE FROM apps WHERE name LIKE '%s'",
-		"DELETE FROM service_keys WHERE name LIKE '%s'",
-		"DELETE FROM s_b USING service_bindings s_b, service_instances s_i WHERE s_i.guid = s_b.service_instance_guid AND s_i.name LIKE '%s'",
-		"DELETE FROM service_instances WHERE name LIKE '%s'",
-		"DELETE FROM s_g_s USING security_groups_spaces s_g_s, security_groups s_g WHERE s_g_s.security_group_id = s_g.id AND s_g.name LIKE '%s'",
-		"DELETE FROM s_g_s USING security_groups_spaces s_g_s, spaces s WHERE s_g_s.space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM security_groups WHERE name LIKE '%s'",
-		"DELETE FROM s_m USING spaces_managers s_m, spaces s WHERE s_m.space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM s_d USING spaces_developers s_d, spaces s WHERE s_d.space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM s_s USING spaces_supporters s_s, spaces s WHERE s_s.space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM s_a USING spaces_auditors s_a, spaces s WHERE s_a.space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM o_m USING organizations_managers o_m, organizations o WHERE o_m.organization_id = o.id AND o.name LIKE '%s'",
-		"DELETE FROM o_b_m USING organizations_billing_managers o_b_m, organizations o WHERE o_b_m.organization_id = o.id AND o.name LIKE '%s'",
-		"DELETE FROM o_a USING organizations_auditors o_a, organizations o WHERE o_a.organization_id = o.id AND o.name LIKE '%s'",
-		"DELETE FROM o_u USING organizations_users o_u, organizations o WHERE o_u.organization_id = o.id AND o.name LIKE '%s'",
-		"DELETE FROM u USING users u, spaces s WHERE u.default_space_id = s.id AND s.name LIKE '%s'",
-		"DELETE FROM s_l USING space_labels s_l, spaces s WHERE s_l.resource_guid = s.guid AND s.name LIKE '%s'",
-		"DELETE FROM spaces WHERE name LIKE '%s'",
-		"DELETE FROM s_p_v USING service_plan_visibilities s_p_v, organizations o WHERE s_p_v.organization_id = o.id AND o.name LIKE '%s'",
-		"DELETE FROM o_i_s USING organizations_isolation_segments o_i_s, organizations o WHERE o_i_s.organization_guid = o.guid AND o.name LIKE '%s'",
-		"DELETE FROM organizations WHERE name LIKE '%s'",
-		"DELETE FROM i_s_a USING isolation_segment_annotations i_s_a, isolation_segments i_s WHERE i_s_a.resource_guid = i_s.guid AND i_s.name LIKE '%s'",
-		"DELETE FROM isolation_segments WHERE name LIKE '%s'",
-		"DELETE FROM s_p_v USING service_plan_visibilities s_p_v, service_plans s_p WHERE s_p.id = s_p_v.service_plan_id AND s_p.name LIKE '%s'",
-		"DELETE FROM service_plans WHERE name LIKE '%s'",
-		"DELETE FROM services WHERE label LIKE '%s'",
-		"DELETE FROM service_brokers WHERE name LIKE '%s'",
-		"DELETE FROM events WHERE actee_name LIKE '%s'",
-		"DELETE FROM events WHERE actee LIKE '%s'",
-		"DELETE FROM quota_definitions WHERE name LIKE '%s'",
-	}
+	RequireTestEnvironment(ccdb, ctx, testConfig, "clean up")
+
 	nameQuery := fmt.Sprintf("%s-%%", testConfig.GetNamePrefix())
+	executeCleanupStatement(ccdb, ctx, testConfig, fmt.Sprintf(cleanupAppDropletsStatement, nameQuery))
+	failedDeletes := deleteRecordedRows(ccdb, ctx, testConfig)
+
+	// resources not created by the Seeder, e.g. via the API, are deleted by their names
 	log.Printf("%v Cleaning up db...\n", time.Now().Format(time.RFC850))
-	if testConfig.DatabaseType == PsqlDb {
-		for _, statement := range deleteStatementsPostgres {
-			ExecuteStatement(ccdb, ctx, fmt.Sprintf(statement, nameQuery))
+	for _, cleanup := range orderCleanupDeletes(ccdb, ctx, testConfig) {
+		statement := fmt.Sprintf("DELETE FROM %s WHERE %s", cleanup.table, fmt.Sprintf(cleanup.condition, nameQuery))
+		if rowsAffected := executeCleanupStatement(ccdb, ctx, testConfig, statement); rowsAffected > 0 {
+			log.Printf("%d rows not recorded in the cleanup manifest affected by '%s'", rowsAffected, statement)
 		}
-		ExecuteStatement(ccdb, ctx, fmt.Sprintf("DROP TABLE IF EXISTS event_types"))
-		ExecuteStatement(ccdb, ctx, fmt.Sprintf("TRUNCATE events"))
-		log.Printf("%v Running 'VACUUM FULL' on db...\n", time.Now().Format(time.RFC850))
-		ExecuteStatement(ccdb, ctx, "VACUUM FULL;")
 	}
-
-	if testConfig.DatabaseType == MysqlDb {
-		for _, statement := range deleteStatementsMySql {
-			ExecuteStatement(ccdb, ctx, fmt.Sprintf(statement, nameQuery))
+	retryRecordedRowDeletes(ccdb, ctx, testConfig, failedDeletes)
+	executeCleanupStatement(ccdb, ctx, testConfig, "DROP TABLE IF EXISTS event_types")
+
+	// the events table and VACUUM FULL affect data not created by the tests
+	if testConfig.Safety.DestructiveCleanup {
+		executeCleanupStatement(ccdb, ctx, testConfig, "TRUNCATE events")
+		if testConfig.DatabaseType == PsqlDb {
+			log.Printf("%v Running 'VACUUM FULL' on db...\n", time.Now().Format(time.RFC850))
+			executeCleanupStatement(ccdb, ctx, testConfig, "VACUUM FULL;")
 		}
-		ExecuteStatement(ccdb, ctx, fmt.Sprintf("DROP TABLE IF EXISTS event_types"))
-		ExecuteStatement(ccdb, ctx, fmt.Sprintf("TRUNCATE events"))
+	} else {
+		log.Printf("Skipping 'TRUNCATE events' and 'VACUUM FULL', set safety.destructive_cleanup to run them")
 	}
 
 	if uaadb != nil {
 		userGuids := ExecuteSelectStatement(uaadb, ctx, fmt.Sprintf("SELECT id FROM users WHERE username LIKE '%s'", nameQuery))
 
 		for _, userGuid := range userGuids {
-			ExecuteStatement(ccdb, ctx, fmt.Sprintf("DELETE FROM users WHERE guid = '%s'", userGuid))
+			executeCleanupStatement(ccdb, ctx, testConfig, fmt.Sprintf("DELETE FROM users WHERE guid = '%s'", userGuid))
 		}
 
-		ExecuteStatement(uaadb, ctx, fmt.Sprintf("DELETE FROM users WHERE username LIKE '%s'", nameQuery))
+		executeCleanupStatement(uaadb, ctx, testConfig, fmt.Sprintf("DELETE FROM users WHERE username LIKE '%s'", nameQuery))
 	}
 }
 
 func AnalyzeDB(ccdb *sql.DB, ctx context.Context, testConfig Config) {
 	if testConfig.DatabaseType == PsqlDb {
+		// like in CleanupTestData, VACUUM FULL affects data not created by the tests
+		if testConfig.Safety.DestructiveCleanup {
+			RequireTestEnvironment(ccdb, ctx, testConfig, "vacuum")
+			log.Printf("%v Running 'VACUUM FULL' on db...\n", time.Now().Format(time.RFC850))
+			executeCleanupStatement(ccdb, ctx, testConfig, "VACUUM FULL;")
+		} else {
+			log.Printf("Skipping 'VACUUM FULL', set safety.destructive_cleanup to run it")
+		}
 		log.Printf("%v Running 'ANALYZE' on db...\n", time.Now().Format(time.RFC850))
-		ExecuteStatement(ccdb, ctx, "VACUUM FULL;")
 		ExecuteStatement(ccdb, ctx, "ANALYZE;")
 	}
 	log.Printf("%v Waiting for Database to stabilize\n", time.Now().Format(time.RFC850))
 	time.Sleep(120 * time.Second)
 }
 
-func ExecuteStoredProcedure(db *sql.DB, ctx context.Context, statement string, testConfig Config) {
-	sqlCmd := ""
-	switch testConfig.DatabaseType {
-	case PsqlDb:
-		sqlCmd = "SELECT FROM "
-	case MysqlDb:
-		sqlCmd = "CALL "
-	}
-	log.Printf("Executing stored procedure: %s", sqlCmd+statement)
-	ExecuteStatement(db, ctx, sqlCmd+statement)
-	log.Printf("Finished stored procedure: %s", sqlCmd+statement)
-}
-
 func ExecuteStatement(db *sql.DB, ctx context.Context, statement string) {
 	result, err := db.ExecContext(ctx, statement)
 	checkError(err)
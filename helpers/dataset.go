@@ -0,0 +1,545 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+)
+
+// Dataset describes the test data of a suite: parameters, and the steps that create the data with a Seeder. It is
+// read from `datasets/<variant>.yml` (or `.json`) in the directory of the suite, e.g.
+//
+//	parameters:
+//	  orgs: 100000
+//	  orgs_assigned_to_regular_user: orgs / 10
+//	steps:
+//	  - step: create_orgs
+//	    count: orgs
+//	  - step: create_selected_orgs_table
+//	    count: orgs_assigned_to_regular_user
+//	  - step: assign_user_org_role
+//	    user: regular
+//	    role: organizations_managers
+//	    orgs: orgs_assigned_to_regular_user
+//
+// Numeric values are integers or expressions with +, -, *, / and parentheses over integers and parameter names.
+// String values like users are resolved with the variables passed to Seed, e.g. the GUID of the regular user.
+//
+// Parameters given as plain numbers are multiplied with testConfig.Scale, so that expressions over them scale
+// along. Parameters set for the suite in testConfig.Suites replace those of the dataset and are not scaled.
+type Dataset struct {
+	Name       string
+	parameters map[string]interface{}
+	steps      []map[string]interface{}
+	values     map[string]int
+	scale      float64
+	overridden map[string]bool
+	// the parameters being evaluated, to detect cycles
+	evaluating map[string]bool
+
+	// identify the dataset for snapshots: the suite directory and dataset name, and a hash of the dataset file
+	snapshotName string
+	hash         string
+	snapshots    bool
+}
+
+// datasetStep creates data with the seeder and returns the id of the created resource, if there is one; it can be
+// referenced by later steps via the name given as `as`.
+type datasetStep func(seeder *Seeder, args *datasetArgs) int
+
+var datasetSteps = map[string]datasetStep{
+	"create_orgs": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateOrgs(args.Int("count"))
+		return 0
+	},
+	"create_selected_orgs_table": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateSelectedOrgsTable(args.Int("count"))
+		return 0
+	},
+	"create_spaces": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateSpaces(args.Int("per_org"))
+		return 0
+	},
+	"create_security_groups": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateSecurityGroups(args.Int("count"))
+		return 0
+	},
+	"assign_security_groups_to_spaces": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.AssignSecurityGroupsToSpaces(args.Int("spaces"), args.Int("security_groups_per_space"))
+		return 0
+	},
+	"assign_user_org_role": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.AssignUserAsOrgRole(args.String("user"), args.String("role"), args.Int("orgs"))
+		return 0
+	},
+	"assign_user_disjoint_org_roles": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.AssignUserDisjointOrgRoles(args.String("user"))
+		return 0
+	},
+	"assign_user_space_role": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.AssignUserAsSpaceRole(args.String("user"), args.String("role"), args.Int("spaces"))
+		return 0
+	},
+	"create_apps": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateApps(args.Int("per_space"))
+		return 0
+	},
+	"create_shared_domains": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateSharedDomains(args.Int("count"))
+		return 0
+	},
+	"create_private_domains": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreatePrivateDomains(args.Int("count"))
+		return 0
+	},
+	"create_isolation_segments": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateIsolationSegments(args.Int("count"))
+		return 0
+	},
+	"assign_orgs_to_isolation_segments": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.AssignOrgsToIsolationSegments(args.Int("orgs"))
+		return 0
+	},
+	"create_org_quotas_and_distribute_orgs": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateOrgQuotasAndDistributeOrgs(args.Int("count"))
+		return 0
+	},
+	"create_service_broker": func(seeder *Seeder, args *datasetArgs) int {
+		return seeder.CreateServiceBroker()
+	},
+	"create_services_and_plans": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateServicesAndPlans(args.Int("services"), args.Int("service_broker"), args.Int("plans_per_service"), args.Bool("public"),
+			args.Int("visible_orgs_per_plan"), args.Bool("boilerplate"))
+		return 0
+	},
+	"create_service_instances_for_orgs_spaces_plans": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateServiceInstancesForOrgsSpacesPlans(args.Int("orgs"), args.Int("spaces_per_org"), args.Int("service_plans"), args.Int("instances_per_plan_per_space"))
+		return 0
+	},
+	"create_service_instance_shares": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateServiceInstanceShares(args.Int("orgs"), args.Int("spaces_per_org"), args.Int("shares_per_space"))
+		return 0
+	},
+	"create_service_instances_for_random_visible_plan": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateServiceInstancesForRandomVisiblePlan(args.Int("count"))
+		return 0
+	},
+	"create_audit_events": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateAuditEvents()
+		return 0
+	},
+	"create_users_with_org_and_space_roles": func(seeder *Seeder, args *datasetArgs) int {
+		seeder.CreateUsersWithOrgAndSpaceRoles(args.String("org_guid"), args.String("space_guid"), args.Int("users"))
+		return 0
+	},
+}
+
+// the dataset of the current suite, whose parameters are recorded in the JSON report
+var loadedDataset *Dataset
+
+// LoadDataset reads the dataset variant configured in testConfig from the datasets directory of the current suite.
+func LoadDataset(testConfig Config) *Dataset {
+	v := viper.New()
+	v.SetConfigName(testConfig.Dataset)
+	v.AddConfigPath("datasets")
+	err := v.ReadInConfig()
+	if err != nil {
+		log.Fatalf("error loading dataset '%s': %s", testConfig.Dataset, err.Error())
+	}
+
+	content, err := os.ReadFile(v.ConfigFileUsed())
+	if err != nil {
+		log.Fatalf("error loading dataset '%s': %s", testConfig.Dataset, err.Error())
+	}
+	suiteDir, err := filepath.Abs(".")
+	if err != nil {
+		log.Fatalf("error loading dataset '%s': %s", testConfig.Dataset, err.Error())
+	}
+
+	suiteName := filepath.Base(filepath.Dir(suiteDir))
+	dataset := &Dataset{
+		Name:         testConfig.Dataset,
+		parameters:   v.GetStringMap("parameters"),
+		values:       map[string]int{},
+		scale:        testConfig.Scale,
+		overridden:   map[string]bool{},
+		snapshotName: fmt.Sprintf("%s/%s/%s", suiteName, filepath.Base(suiteDir), testConfig.Dataset),
+		snapshots:    testConfig.Snapshots,
+	}
+	if dataset.scale == 0 {
+		dataset.scale = 1
+	}
+	for name, value := range testConfig.Suites[suiteName] {
+		name = strings.ToLower(name)
+		if _, found := dataset.parameters[name]; !found {
+			log.Fatalf("error loading dataset '%s': 'suites.%s.%s' is not a parameter of the dataset", dataset.Name, suiteName, name)
+		}
+		dataset.parameters[name] = value
+		dataset.overridden[name] = true
+	}
+
+	steps, ok := v.Get("steps").([]interface{})
+	if v.IsSet("steps") && !ok {
+		log.Fatalf("error loading dataset '%s': 'steps' must be a list", dataset.Name)
+	}
+	for i, step := range steps {
+		args, ok := step.(map[string]interface{})
+		if !ok {
+			log.Fatalf("error loading dataset '%s': step %d must be a map", dataset.Name, i+1)
+		}
+		dataset.steps = append(dataset.steps, args)
+	}
+
+	// evaluate all parameters up front, so that errors show up before any data is created
+	for name := range dataset.parameters {
+		dataset.Int(name)
+	}
+	// snapshots are only restored for the same file and the same effective parameters
+	parameters, err := json.Marshal(dataset.Parameters())
+	checkError(err)
+	dataset.hash = fmt.Sprintf("%x", sha256.Sum256(append(content, parameters...)))
+
+	loadedDataset = dataset
+	return dataset
+}
+
+// Parameters returns the effective values of all parameters.
+func (dataset *Dataset) Parameters() map[string]int {
+	parameters := map[string]int{}
+	for name := range dataset.parameters {
+		parameters[name] = dataset.Int(name)
+	}
+	return parameters
+}
+
+// Int returns the value of the named parameter.
+func (dataset *Dataset) Int(name string) int {
+	value, err := dataset.value(name)
+	if err != nil {
+		log.Fatalf("dataset '%s': %s", dataset.Name, err.Error())
+	}
+	return value
+}
+
+// value evaluates the named parameter, scaled if it is a plain number, and caches the result.
+func (dataset *Dataset) value(name string) (int, error) {
+	name = strings.ToLower(name)
+	if value, found := dataset.values[name]; found {
+		return value, nil
+	}
+	expression, found := dataset.parameters[name]
+	if !found {
+		return 0, fmt.Errorf("no parameter '%s'", name)
+	}
+
+	// guard against parameters referring to themselves, directly or via other parameters
+	if dataset.evaluating[name] {
+		return 0, fmt.Errorf("parameter '%s' refers to itself", name)
+	}
+	if dataset.evaluating == nil {
+		dataset.evaluating = map[string]bool{}
+	}
+	dataset.evaluating[name] = true
+	value, err := dataset.evaluate(expression)
+	delete(dataset.evaluating, name)
+	if err != nil {
+		return 0, fmt.Errorf("parameter '%s': %w", name, err)
+	}
+	if !dataset.overridden[name] && isNumber(expression) {
+		value = scaled(value, dataset.scale)
+	}
+	dataset.values[name] = value
+	return value, nil
+}
+
+func isNumber(expression interface{}) bool {
+	if text, ok := expression.(string); ok {
+		_, err := strconv.Atoi(strings.TrimSpace(text))
+		return err == nil
+	}
+	return true
+}
+
+// scaled multiplies the value with the scale; positive values stay at least 1, so that no step is left out.
+func scaled(value int, scale float64) int {
+	result := int(math.Round(float64(value) * scale))
+	if value > 0 && result < 1 {
+		return 1
+	}
+	return result
+}
+
+// Seed runs the steps of the dataset. The variables name values only known at runtime, e.g. "regular" for the GUID
+// of the regular user.
+//
+// With testConfig.Snapshots, the data created by the steps before the first step using a variable is snapshotted
+// after seeding, and restored instead of running these steps again as long as the dataset file, the CCDB schema
+// version and the test resource prefix are unchanged. Steps using variables, e.g. role assignments of the regular user, are always run.
+func (dataset *Dataset) Seed(seeder *Seeder, variables map[string]string) {
+	log.Printf("Seeding dataset '%s'...", dataset.Name)
+
+	snapshotSteps := len(dataset.steps)
+	for i, step := range dataset.steps {
+		if usesVariables(step, variables) {
+			snapshotSteps = i
+			break
+		}
+	}
+
+	var snapshot *Snapshot
+	if dataset.snapshots && snapshotSteps > 0 {
+		snapshot = seeder.NewSnapshot(dataset.snapshotName, dataset.hash)
+	}
+
+	if snapshot == nil || !snapshot.Restore() {
+		if snapshot != nil {
+			snapshot.Begin()
+		}
+		for i := 0; i < snapshotSteps; i++ {
+			dataset.runStep(seeder, i, variables)
+		}
+		if snapshot != nil {
+			snapshot.Take()
+		}
+	}
+	for i := snapshotSteps; i < len(dataset.steps); i++ {
+		dataset.runStep(seeder, i, variables)
+	}
+}
+
+func (dataset *Dataset) runStep(seeder *Seeder, i int, variables map[string]string) {
+	step := dataset.steps[i]
+	args := &datasetArgs{dataset: dataset, step: i + 1, args: step, variables: variables, used: map[string]bool{"step": true, "as": true}}
+	name := args.String("step")
+	create, found := datasetSteps[name]
+	if !found {
+		log.Fatalf("dataset '%s': step %d: unknown step '%s'", dataset.Name, i+1, name)
+	}
+
+	id := create(seeder, args)
+
+	args.checkUnused()
+	if ref, found := step["as"]; found {
+		dataset.values[strings.ToLower(fmt.Sprint(ref))] = id
+	}
+}
+
+func usesVariables(step map[string]interface{}, variables map[string]string) bool {
+	for _, value := range step {
+		if name, ok := value.(string); ok {
+			if _, found := variables[name]; found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type datasetArgs struct {
+	dataset   *Dataset
+	step      int
+	args      map[string]interface{}
+	variables map[string]string
+	used      map[string]bool
+}
+
+func (args *datasetArgs) get(name string) interface{} {
+	value, found := args.args[name]
+	if !found {
+		args.fail("missing argument '%s'", name)
+	}
+	args.used[name] = true
+	return value
+}
+
+func (args *datasetArgs) Int(name string) int {
+	value, err := args.dataset.evaluate(args.get(name))
+	if err != nil {
+		args.fail("argument '%s': %s", name, err.Error())
+	}
+	return value
+}
+
+func (args *datasetArgs) Bool(name string) bool {
+	value, ok := args.get(name).(bool)
+	if !ok {
+		args.fail("argument '%s' must be true or false", name)
+	}
+	return value
+}
+
+// String returns the value of the variable named by the argument, or the argument itself if there is no such
+// variable.
+func (args *datasetArgs) String(name string) string {
+	value := fmt.Sprint(args.get(name))
+	if variable, found := args.variables[value]; found {
+		return variable
+	}
+	return value
+}
+
+func (args *datasetArgs) checkUnused() {
+	var unused []string
+	for name := range args.args {
+		if !args.used[name] {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		args.fail("unknown arguments %s", strings.Join(unused, ", "))
+	}
+}
+
+func (args *datasetArgs) fail(format string, a ...interface{}) {
+	log.Fatalf("dataset '%s': step %d: %s", args.dataset.Name, args.step, fmt.Sprintf(format, a...))
+}
+
+func (dataset *Dataset) evaluate(expression interface{}) (int, error) {
+	switch value := expression.(type) {
+	case int:
+		return value, nil
+	case int64:
+		return int(value), nil
+	case float64:
+		if value != float64(int(value)) {
+			return 0, fmt.Errorf("'%v' is not an integer", value)
+		}
+		return int(value), nil
+	case string:
+		parser := &expressionParser{dataset: dataset, tokens: tokenize(value)}
+		result, err := parser.sum()
+		if err == nil && parser.position < len(parser.tokens) {
+			err = fmt.Errorf("unexpected '%s'", parser.tokens[parser.position])
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid expression '%s': %w", value, err)
+		}
+		return result, nil
+	default:
+		return 0, fmt.Errorf("'%v' is not a number", expression)
+	}
+}
+
+// expressionParser evaluates integer expressions over the parameters of a dataset.
+type expressionParser struct {
+	dataset  *Dataset
+	tokens   []string
+	position int
+}
+
+func (p *expressionParser) sum() (int, error) {
+	result, err := p.product()
+	for err == nil && p.peek("+", "-") {
+		operator := p.next()
+		var operand int
+		operand, err = p.product()
+		if operator == "+" {
+			result += operand
+		} else {
+			result -= operand
+		}
+	}
+	return result, err
+}
+
+func (p *expressionParser) product() (int, error) {
+	result, err := p.operand()
+	for err == nil && p.peek("*", "/") {
+		operator := p.next()
+		var operand int
+		operand, err = p.operand()
+		switch {
+		case err != nil:
+		case operator == "*":
+			result *= operand
+		case operand == 0:
+			err = fmt.Errorf("division by zero")
+		default:
+			result /= operand
+		}
+	}
+	return result, err
+}
+
+func (p *expressionParser) operand() (int, error) {
+	if p.position >= len(p.tokens) {
+		return 0, fmt.Errorf("unexpected end")
+	}
+	token := p.next()
+	switch {
+	case token == "(":
+		result, err := p.sum()
+		if err == nil && !p.peek(")") {
+			err = fmt.Errorf("missing ')'")
+		}
+		p.position++
+		return result, err
+	case token == "-":
+		result, err := p.operand()
+		return -result, err
+	case unicode.IsDigit(rune(token[0])):
+		return strconv.Atoi(token)
+	case unicode.IsLetter(rune(token[0])) || token[0] == '_':
+		name := strings.ToLower(token)
+		if value, found := p.dataset.values[name]; found {
+			return value, nil
+		}
+		if _, found := p.dataset.parameters[name]; !found {
+			return 0, fmt.Errorf("unknown parameter '%s'", token)
+		}
+		return p.dataset.value(name)
+	default:
+		return 0, fmt.Errorf("unexpected '%s'", token)
+	}
+}
+
+func (p *expressionParser) peek(tokens ...string) bool {
+	if p.position >= len(p.tokens) {
+		return false
+	}
+	for _, token := range tokens {
+		if p.tokens[p.position] == token {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *expressionParser) next() string {
+	token := p.tokens[p.position]
+	p.position++
+	return token
+}
+
+func tokenize(expression string) []string {
+	var tokens []string
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestDataset(parameters map[string]interface{}, scale float64) *Dataset {
+	return &Dataset{Name: "test", parameters: parameters, values: map[string]int{}, scale: scale, overridden: map[string]bool{}}
+}
+
+func TestDatasetExpressions(t *testing.T) {
+	tests := []struct {
+		expression interface{}
+		expected   int
+	}{
+		{7, 7},
+		{float64(7), 7},
+		{"7", 7},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"100 / 10 / 5", 2},
+		{"7 / 2", 3},
+		{"-3 + 5", 2},
+		{"2 * -(1 + 2)", -6},
+		{"((2))", 2},
+		{"orgs / 10", 10},
+		{"ORGS * spaces_per_org", 300},
+		{"orgs_per_quota * 2", 20},
+	}
+	for _, test := range tests {
+		dataset := newTestDataset(map[string]interface{}{"orgs": 100, "spaces_per_org": "3", "orgs_per_quota": "orgs / 10"}, 1)
+		actual, err := dataset.evaluate(test.expression)
+		if err != nil || actual != test.expected {
+			t.Errorf("evaluate(%v) = %d, %v, expected %d", test.expression, actual, err, test.expected)
+		}
+	}
+}
+
+func TestDatasetExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expression interface{}
+		expected   string
+	}{
+		{"10 / 0", "division by zero"},
+		{"10 / (orgs - 100)", "division by zero"},
+		{"unknown * 2", "unknown parameter 'unknown'"},
+		{"(1 + 2", "missing ')'"},
+		{"1 +", "unexpected end"},
+		{"1 2", "unexpected '2'"},
+		{"1 % 2", "unexpected '%'"},
+		{"", "unexpected end"},
+		{1.5, "is not an integer"},
+		{true, "is not a number"},
+	}
+	for _, test := range tests {
+		dataset := newTestDataset(map[string]interface{}{"orgs": 100}, 1)
+		_, err := dataset.evaluate(test.expression)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("evaluate(%v) returned error %v, expected '%s'", test.expression, err, test.expected)
+		}
+	}
+}
+
+func TestDatasetCyclicParameters(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"a": "a + 1"},
+		{"a": "b", "b": "a"},
+		{"a": "b * 2", "b": "c", "c": "a / 2"},
+	}
+	for _, parameters := range tests {
+		dataset := newTestDataset(parameters, 1)
+		_, err := dataset.value("a")
+		if err == nil || !strings.Contains(err.Error(), "parameter 'a' refers to itself") {
+			t.Errorf("value(a) of %v returned error %v, expected a cycle", parameters, err)
+		}
+	}
+}
+
+func TestDatasetUnknownParameter(t *testing.T) {
+	dataset := newTestDataset(map[string]interface{}{"orgs": 100}, 1)
+	_, err := dataset.value("spaces")
+	if err == nil || !strings.Contains(err.Error(), "no parameter 'spaces'") {
+		t.Errorf("value(spaces) returned error %v", err)
+	}
+}
+
+func TestDatasetScaling(t *testing.T) {
+	dataset := newTestDataset(map[string]interface{}{
+		"orgs":   1000,
+		"spaces": "500",
+		"quotas": "orgs / 10",
+		"users":  3,
+		"none":   0,
+		"fixed":  1000,
+	}, 0.01)
+	dataset.overridden["fixed"] = true
+
+	expected := map[string]int{
+		// plain numbers are scaled
+		"orgs":   10,
+		"spaces": 5,
+		// expressions scale along with the parameters they refer to
+		"quotas": 1,
+		// positive values stay at least 1
+		"users": 1,
+		"none":  0,
+		// overridden parameters are not scaled
+		"fixed": 1000,
+	}
+	for name, value := range expected {
+		if actual := dataset.Int(name); actual != value {
+			t.Errorf("Int(%s) = %d, expected %d", name, actual, value)
+		}
+	}
+}
+
+func TestScaled(t *testing.T) {
+	tests := []struct {
+		value    int
+		scale    float64
+		expected int
+	}{
+		{100, 1, 100},
+		{100, 0.5, 50},
+		{100, 2.5, 250},
+		{3, 0.5, 2},
+		{1, 0.01, 1},
+		{0, 0.01, 0},
+		{-10, 0.5, -5},
+	}
+	for _, test := range tests {
+		if actual := scaled(test.value, test.scale); actual != test.expected {
+			t.Errorf("scaled(%d, %v) = %d, expected %d", test.value, test.scale, actual, test.expected)
+		}
+	}
+}
+
+func TestIsNumber(t *testing.T) {
+	tests := []struct {
+		expression interface{}
+		expected   bool
+	}{
+		{100, true},
+		{float64(100), true},
+		{"100", true},
+		{" 100 ", true},
+		{"orgs / 10", false},
+		{"10 * 10", false},
+		{"orgs", false},
+	}
+	for _, test := range tests {
+		if actual := isNumber(test.expression); actual != test.expected {
+			t.Errorf("isNumber(%v) = %t, expected %t", test.expression, actual, test.expected)
+		}
+	}
+}
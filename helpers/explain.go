@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+// at most this many statements are explained per experiment
+const maxExplainedStatements = 50
+
+// the CCDB opened by OpenDbConnections, used to inspect the statements issued by the Cloud Controller
+var ccdbConnection *sql.DB
+
+var placeholderPattern = regexp.MustCompile(`\$\d+`)
+
+// QueryPlan is the plan of a statement the Cloud Controller executed during an experiment. Generic plans were
+// explained without executing the statement, so they contain no execution statistics.
+type QueryPlan struct {
+	Query   string          `json:"query"`
+	Plan    json.RawMessage `json:"plan,omitempty"`
+	Generic bool            `json:"generic,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// QueryPlans are added as report entry next to the experiment and written to a separate file by GenerateReports.
+type QueryPlans struct {
+	Experiment string      `json:"experiment"`
+	Plans      []QueryPlan `json:"plans"`
+}
+
+// explainStatements runs one additional sample after the experiment, collects the SQL statements executed on the
+// CCDB meanwhile from pg_stat_statements (PostgreSQL) or performance_schema.events_statements_history_long (MySQL),
+// and explains them. The sample is not recorded, and as it runs after the measured samples (and after all workers
+// of the load mode have stopped), neither the statistics reset nor EXPLAIN ANALYZE affect the measurements.
+// Failed assertions of the sample are logged instead of failing the spec.
+func explainStatements(experiment *gmeasure.Experiment, testConfig Config, sampler func(idx int)) {
+	if ccdbConnection == nil {
+		log.Printf("Cannot capture query plans without a CCDB connection")
+		return
+	}
+	ctx := context.Background()
+
+	err := resetStatementStatistics(ctx, testConfig)
+	if err != nil {
+		log.Printf("Cannot capture query plans of '%s': %s", experiment.Name, err.Error())
+		return
+	}
+	withoutRecording(experiment, func() {
+		for _, failure := range InterceptGomegaFailures(func() { sampler(0) }) {
+			log.Printf("Sample capturing the query plans of '%s' failed: %s", experiment.Name, failure)
+		}
+	})
+	statements, err := executedStatements(ctx, testConfig)
+	if err != nil {
+		log.Printf("Cannot capture query plans of '%s': %s", experiment.Name, err.Error())
+		return
+	}
+
+	plans := QueryPlans{Experiment: experiment.Name}
+	for _, statement := range statements {
+		plans.Plans = append(plans.Plans, explainStatement(ctx, testConfig, statement))
+	}
+	AddReportEntry(fmt.Sprintf("%s query plans", experiment.Name), plans, ReportEntryVisibilityNever)
+}
+
+// withoutRecording runs f and removes everything it recorded in the experiment afterwards.
+func withoutRecording(experiment *gmeasure.Experiment, f func()) {
+	recorded := map[string][3]int{}
+	for _, m := range experiment.Measurements {
+		recorded[m.Name] = [3]int{len(m.Durations), len(m.Values), len(m.Annotations)}
+	}
+	f()
+
+	measurements := experiment.Measurements[:0]
+	for _, m := range experiment.Measurements {
+		lengths, found := recorded[m.Name]
+		if !found {
+			continue
+		}
+		m.Durations = m.Durations[:lengths[0]]
+		m.Values = m.Values[:lengths[1]]
+		m.Annotations = m.Annotations[:lengths[2]]
+		measurements = append(measurements, m)
+	}
+	experiment.Measurements = measurements
+}
+
+func resetStatementStatistics(ctx context.Context, testConfig Config) error {
+	var err error
+	if testConfig.DatabaseType == PsqlDb {
+		_, err = ccdbConnection.ExecContext(ctx, "SELECT pg_stat_statements_reset()")
+	} else {
+		_, err = ccdbConnection.ExecContext(ctx, "TRUNCATE TABLE performance_schema.events_statements_history_long")
+	}
+	return err
+}
+
+func executedStatements(ctx context.Context, testConfig Config) ([]string, error) {
+	var query string
+	if testConfig.DatabaseType == PsqlDb {
+		query = fmt.Sprintf("SELECT query FROM pg_stat_statements WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database()) "+
+			"AND query NOT LIKE '%%pg_stat_statements%%' ORDER BY calls DESC LIMIT %d", maxExplainedStatements)
+	} else {
+		query = fmt.Sprintf("SELECT DISTINCT SQL_TEXT FROM performance_schema.events_statements_history_long WHERE CURRENT_SCHEMA = DATABASE() "+
+			"AND SQL_TEXT IS NOT NULL AND SQL_TEXT NOT LIKE '%%performance_schema%%' LIMIT %d", maxExplainedStatements)
+	}
+	rows, err := ccdbConnection.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, rows.Err()
+}
+
+// explainStatement explains the statement if it is a query; other statements are not executed again.
+//
+// On PostgreSQL, pg_stat_statements replaces the constants of most statements with placeholders ($1, ...), so that
+// the actual statements cannot be executed again. For these, only the generic plan is explained, with
+// EXPLAIN (GENERIC_PLAN), which requires PostgreSQL 16 or later and contains neither ANALYZE nor BUFFERS statistics;
+// on older versions the error is recorded instead of a plan. EXPLAIN (ANALYZE, BUFFERS) is only run for statements
+// without placeholders.
+func explainStatement(ctx context.Context, testConfig Config, statement string) QueryPlan {
+	queryPlan := QueryPlan{Query: statement}
+	keyword := strings.ToUpper(strings.SplitN(strings.TrimSpace(statement), " ", 2)[0])
+	if keyword != "SELECT" && keyword != "WITH" {
+		queryPlan.Error = "not a query"
+		return queryPlan
+	}
+
+	var explain string
+	switch {
+	case testConfig.DatabaseType == MysqlDb:
+		explain = "EXPLAIN FORMAT=JSON " + statement
+	case placeholderPattern.MatchString(statement):
+		explain = "EXPLAIN (GENERIC_PLAN, FORMAT JSON) " + statement
+		queryPlan.Generic = true
+	default:
+		explain = "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) " + statement
+	}
+
+	var plan string
+	err := ccdbConnection.QueryRowContext(ctx, explain).Scan(&plan)
+	if err != nil {
+		queryPlan.Error = err.Error()
+		return queryPlan
+	}
+	queryPlan.Plan = json.RawMessage(plan)
+	return queryPlan
+}
+
+// writeQueryPlans writes the query plans next to the result file, keyed like the measurements.
+func writeQueryPlans(reporter *JsonReporter, plans []QueryPlans) {
+	if len(plans) == 0 {
+		return
+	}
+	plansByExperiment := map[string][]QueryPlan{}
+	for _, experimentPlans := range plans {
+		plansByExperiment[fmt.Sprintf("%s::%s", reporter.testHeadlineName, experimentPlans.Experiment)] = experimentPlans.Plans
+	}
+
+	data, err := json.MarshalIndent(plansByExperiment, "", "  ")
+	if err != nil {
+		fmt.Println("Failed to marshal query plans")
+		return
+	}
+	err = os.WriteFile(strings.TrimSuffix(reporter.outputFile, ".json")+"-query-plans.json", data, 0644)
+	if err != nil {
+		fmt.Println("Failed to write query plans")
+	}
+}
@@ -7,91 +7,73 @@ import (
 
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/onsi/gomega/gmeasure"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
 )
 
+// JsonReporter writes the result file of a suite; see the results package for its schema.
 type JsonReporter struct {
-	testSuiteName       string
-	testHeadlineName    string
-	Measurements        map[string]map[string]Measurement `json:"measurements"`
-	outputFile          string
-	CfDeploymentVersion string `json:"cfDeploymentVersion"`
-	Timestamp           int64  `json:"timestamp"`
-	CapiVersion         string `json:"capiVersion"`
-	CCDBVersion         string `json:"ccdbVersion"`
+	results.File
+	testSuiteName    string
+	testHeadlineName string
+	outputFile       string
+	metrics          Metrics
+	budgets          []Budget
+	// the specs that recorded the experiments, keyed like the measurements
+	experimentSpecs map[string]types.SpecReport
 }
 
-type Measurement struct {
-	Name          string      `json:"Name"`
-	Info          interface{} `json:"Info"`
-	Order         int         `json:"Order"`
-	Results       []float64   `json:"Results"`
-	Smallest      float64     `json:"Smallest"`
-	Largest       float64     `json:"Largest"`
-	Average       float64     `json:"Average"`
-	StdDeviation  float64     `json:"StdDeviation"`
-	SmallestLabel string      `json:"SmallestLabel"`
-	LargestLabel  string      `json:"LargestLabel"`
-	AverageLabel  string      `json:"AverageLabel"`
-	Units         string      `json:"Units"`
-}
+type Measurement = results.Measurement
 
 func NewJsonReporter(outputFile string, testHeadlineName string, cfDeploymentVersion string, CapiVersion string, timestamp int64, testSuiteName string, ccdbVersion string) *JsonReporter {
 	return &JsonReporter{
-		testSuiteName:       testSuiteName,
-		testHeadlineName:    testHeadlineName,
-		outputFile:          outputFile,
-		CfDeploymentVersion: cfDeploymentVersion,
-		CapiVersion:         CapiVersion,
-		CCDBVersion:         ccdbVersion,
-		Timestamp:           timestamp,
-		Measurements:        map[string]map[string]Measurement{},
+		File: results.File{
+			SchemaVersion:       results.CurrentSchemaVersion,
+			CfDeploymentVersion: cfDeploymentVersion,
+			CapiVersion:         CapiVersion,
+			CCDBVersion:         ccdbVersion,
+			Timestamp:           timestamp,
+			Measurements:        map[string]map[string]Measurement{},
+		},
+		testSuiteName:    testSuiteName,
+		testHeadlineName: testHeadlineName,
+		outputFile:       outputFile,
+		experimentSpecs:  map[string]types.SpecReport{},
 	}
 }
 
 func GenerateReports(reporter *JsonReporter, report types.Report) {
+	var queryPlans []QueryPlans
 	for _, r := range report.SpecReports {
 		for _, re := range r.ReportEntries {
-			// Set up experiment
+			// Set up experiment; query plans captured for an experiment are written to a separate file
 			var a interface{} = re.Value.GetRawValue()
-			e := a.(*gmeasure.Experiment)
-
-			// Set up measurement
-			m := Measurement{}
-			m.Name = "request time"
-
-			// Attach all results for experiment to measurement
-			exp := e.Get(e.Measurements[0].Name)
-			durations := exp.Durations
-			var floatDurations []float64
-
-			for _, d := range durations {
-				floatDurations = append(floatDurations, d.Seconds())
+			if plans, ok := a.(QueryPlans); ok {
+				queryPlans = append(queryPlans, plans)
+				continue
 			}
+			e := a.(*gmeasure.Experiment)
 
-			m.Results = floatDurations
-
-			// Attach experiment statistics to measurement
-			expStats := e.GetStats(e.Measurements[0].Name)
-			m.Smallest = expStats.DurationBundle[gmeasure.StatMin].Seconds()
-			m.Largest = expStats.DurationBundle[gmeasure.StatMax].Seconds()
-			m.Average = expStats.DurationBundle[gmeasure.StatMean].Seconds()
-			m.StdDeviation = expStats.DurationBundle[gmeasure.StatStdDev].Seconds()
-
-			// Attach labels to measurement
-			m.SmallestLabel = "Smallest"
-			m.LargestLabel = "Largest"
-			m.AverageLabel = "Average"
-			m.Units = "Seconds"
-
-			// Create measurement map structure
+			// Create measurement map structure; the request itself is reported as "request time", and each
+			// request phase recorded by TimeCCRequest as its own series next to it
 			mp := make(map[string]Measurement)
-			mp["request time"] = m
+			for _, measurement := range e.Measurements {
+				if isRequestPhase(measurement.Name) || isLoadMeasurement(measurement.Name) || isQueryMeasurement(measurement.Name) {
+					mp[measurement.Name] = newMeasurement(e, measurement.Name, measurement.Name)
+				} else if _, found := mp["request time"]; !found {
+					mp["request time"] = newMeasurement(e, measurement.Name, "request time")
+				}
+			}
 
 			// Add map to overall reporter structure
-			reporter.Measurements[fmt.Sprintf("%s::%s", reporter.testHeadlineName, e.Name)] = mp
+			key := fmt.Sprintf("%s::%s", reporter.testHeadlineName, e.Name)
+			reporter.Measurements[key] = mp
+			reporter.experimentSpecs[key] = r
 		}
 	}
 
+	failures := evaluateBudgets(reporter)
+
 	data, err := json.Marshal(reporter)
 	if err != nil {
 		fmt.Println("Failed to marshal JSON report data")
@@ -101,4 +83,109 @@ func GenerateReports(reporter *JsonReporter, report types.Report) {
 	if err != nil {
 		fmt.Println("Failed to write JSON report")
 	}
+
+	writeQueryPlans(reporter, queryPlans)
+	exportMetrics(reporter)
+	writeJUnitReports(reporter, report, failures)
+	failExceededBudgets(failures)
+}
+
+func newMeasurement(e *gmeasure.Experiment, measurementName string, name string) Measurement {
+	// Set up measurement
+	m := Measurement{}
+	m.Name = name
+
+	// Attach all results for experiment to measurement
+	exp := e.Get(measurementName)
+	if exp.Type == gmeasure.MeasurementTypeValue {
+		return newValueMeasurement(e, exp, name)
+	}
+	durations := exp.Durations
+	var floatDurations []float64
+
+	for _, d := range durations {
+		floatDurations = append(floatDurations, d.Seconds())
+	}
+
+	m.Results = floatDurations
+
+	// Attach experiment statistics to measurement
+	expStats := e.GetStats(measurementName)
+	m.Smallest = expStats.DurationBundle[gmeasure.StatMin].Seconds()
+	m.Largest = expStats.DurationBundle[gmeasure.StatMax].Seconds()
+	m.Average = expStats.DurationBundle[gmeasure.StatMean].Seconds()
+	m.StdDeviation = expStats.DurationBundle[gmeasure.StatStdDev].Seconds()
+	m.Median = expStats.DurationBundle[gmeasure.StatMedian].Seconds()
+
+	// Attach tail latency percentiles to measurement
+	m.P50 = results.Percentile(floatDurations, 50)
+	m.P90 = results.Percentile(floatDurations, 90)
+	m.P95 = results.Percentile(floatDurations, 95)
+	m.P99 = results.Percentile(floatDurations, 99)
+
+	// Attach labels to measurement
+	m.SmallestLabel = "Smallest"
+	m.LargestLabel = "Largest"
+	m.AverageLabel = "Average"
+	m.Units = "Seconds"
+
+	return m
+}
+
+// newValueMeasurement reports a measurement recorded with RecordValue, e.g. the throughput in load mode, in the
+// units it was recorded with.
+func newValueMeasurement(e *gmeasure.Experiment, exp gmeasure.Measurement, name string) Measurement {
+	m := Measurement{}
+	m.Name = name
+	// the load settings are recorded as annotation of the throughput
+	if len(exp.Annotations) > 0 && exp.Annotations[0] != "" {
+		m.Info = exp.Annotations[0]
+	}
+	m.Results = exp.Values
+
+	expStats := e.GetStats(exp.Name)
+	m.Smallest = expStats.ValueBundle[gmeasure.StatMin]
+	m.Largest = expStats.ValueBundle[gmeasure.StatMax]
+	m.Average = expStats.ValueBundle[gmeasure.StatMean]
+	m.StdDeviation = expStats.ValueBundle[gmeasure.StatStdDev]
+	m.Median = expStats.ValueBundle[gmeasure.StatMedian]
+
+	m.P50 = results.Percentile(exp.Values, 50)
+	m.P90 = results.Percentile(exp.Values, 90)
+	m.P95 = results.Percentile(exp.Values, 95)
+	m.P99 = results.Percentile(exp.Values, 99)
+
+	m.SmallestLabel = "Smallest"
+	m.LargestLabel = "Largest"
+	m.AverageLabel = "Average"
+	m.Units = exp.Units
+
+	return m
+}
+
+func isLoadMeasurement(name string) bool {
+	for _, loadMeasurement := range LoadMeasurements {
+		if name == loadMeasurement {
+			return true
+		}
+	}
+	return false
+}
+
+func isQueryMeasurement(name string) bool {
+	for _, queryMeasurement := range QueryMeasurements {
+		if name == queryMeasurement {
+			return true
+		}
+	}
+	return false
+}
+
+func isRequestPhase(name string) bool {
+	for _, phase := range RequestPhases {
+		if name == phase {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+// The JUnit report lists every experiment as test case, with the statistics of its request time as properties.
+// Ginkgo's own JUnit types do not support properties of test cases, which are understood by most CI systems.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Time       float64          `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       float64         `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Time       float64         `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Skipped    *junitMessage   `xml:"skipped,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message     string `xml:"message,attr"`
+	Type        string `xml:"type,attr"`
+	Description string `xml:",chardata"`
+}
+
+// writeJUnitReports writes <result file>-junit.xml with a test case per experiment, and the report of Ginkgo as
+// <result file>-ginkgo.json. An experiment fails if its spec failed, or with the failures recorded for it. The
+// failures are the latency budgets the experiments exceeded, as returned by evaluateBudgets; test cases only fail on
+// budgets if these are configured (see 'budgets' in the config).
+func writeJUnitReports(reporter *JsonReporter, report types.Report, failures map[string][]string) {
+	base := strings.TrimSuffix(reporter.outputFile, ".json")
+	err := reporters.GenerateJSONReport(report, base+"-ginkgo.json")
+	if err != nil {
+		fmt.Println("Failed to write Ginkgo JSON report")
+	}
+
+	data, err := xml.MarshalIndent(junitReport(reporter, report, failures), "", "  ")
+	if err != nil {
+		fmt.Println("Failed to marshal JUnit report")
+		return
+	}
+	err = os.WriteFile(base+"-junit.xml", append([]byte(xml.Header), data...), 0644)
+	if err != nil {
+		fmt.Println("Failed to write JUnit report")
+	}
+}
+
+func junitReport(reporter *JsonReporter, report types.Report, failures map[string][]string) junitTestSuites {
+	suite := junitTestSuite{
+		Name:      reporter.testSuiteName,
+		Time:      report.RunTime.Seconds(),
+		Timestamp: report.StartTime.Format("2006-01-02T15:04:05"),
+		Properties: []junitProperty{
+			{"test_version", reporter.Run.TestVersion},
+			{"capi_version", reporter.CapiVersion},
+			{"cf_deployment_version", reporter.CfDeploymentVersion},
+			{"ccdb_version", reporter.CCDBVersion},
+			{"dataset", reporter.Dataset},
+		},
+	}
+	classname := fmt.Sprintf("%s.%s", reporter.testSuiteName, reporter.Run.TestVersion)
+
+	var keys []string
+	for key := range reporter.Measurements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	reported := map[string]bool{}
+	for _, key := range keys {
+		spec := reporter.experimentSpecs[key]
+		reported[spec.FullText()] = true
+		testCase := junitTestCase{Name: key, Classname: classname, Time: spec.RunTime.Seconds()}
+		if requestTime, found := reporter.Measurements[key][results.RequestTimeMeasurement]; found {
+			testCase.Properties = measurementProperties(requestTime)
+		}
+		var messages []string
+		if spec.State.Is(types.SpecStateFailureStates) {
+			messages = append(messages, spec.Failure.Message)
+		}
+		messages = append(messages, failures[key]...)
+		if len(messages) > 0 {
+			testCase.Failure = &junitFailure{Message: messages[0], Type: "failed", Description: strings.Join(messages, "\n")}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// specs that failed or were skipped before recording an experiment
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != types.NodeTypeIt || reported[spec.FullText()] {
+			continue
+		}
+		testCase := junitTestCase{Name: spec.FullText(), Classname: classname, Time: spec.RunTime.Seconds()}
+		switch {
+		case spec.State.Is(types.SpecStateFailureStates):
+			testCase.Failure = &junitFailure{Message: spec.Failure.Message, Type: spec.State.String(), Description: spec.Failure.Location.String()}
+		case spec.State.Is(types.SpecStateSkipped | types.SpecStatePending):
+			testCase.Skipped = &junitMessage{Message: spec.Failure.Message}
+		default:
+			continue
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for _, testCase := range suite.TestCases {
+		suite.Tests++
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+		if testCase.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+	return junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Time: suite.Time, TestSuites: []junitTestSuite{suite}}
+}
+
+func measurementProperties(m Measurement) []junitProperty {
+	return []junitProperty{
+		{"samples", fmt.Sprint(len(m.Results))},
+		{"units", m.Units},
+		{"min", fmt.Sprint(m.Smallest)},
+		{"mean", fmt.Sprint(m.Average)},
+		{"median", fmt.Sprint(m.Median)},
+		{"p90", fmt.Sprint(m.P90)},
+		{"p95", fmt.Sprint(m.P95)},
+		{"p99", fmt.Sprint(m.P99)},
+		{"max", fmt.Sprint(m.Largest)},
+		{"stddev", fmt.Sprint(m.StdDeviation)},
+	}
+}
@@ -0,0 +1,170 @@
+package helpers
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+// Names of the values recorded for an experiment run in load mode.
+const (
+	ThroughputMeasurement           = "throughput"
+	ErrorRateMeasurement            = "error rate"
+	StartDelayMeasurement           = "start delay"
+	CorrectedRequestTimeMeasurement = "corrected request time"
+)
+
+var LoadMeasurements = []string{ThroughputMeasurement, ErrorRateMeasurement, StartDelayMeasurement, CorrectedRequestTimeMeasurement}
+
+// loadRun counts the requests sent by TimeCCRequestReturning while an experiment runs in load mode.
+type loadRun struct {
+	requests atomic.Int64
+	failures atomic.Int64
+}
+
+var activeLoadRun atomic.Pointer[loadRun]
+
+// SampleExperiment runs the sampler testConfig.Samples times in sequence. If the load mode is configured, the
+// sampler is instead run by testConfig.Load.Workers parallel workers (limited to testConfig.Load.Rate samples per
+// second in total) for testConfig.Load.Duration, or for testConfig.Samples samples if no duration is set. In load
+// mode, failed requests do not fail the spec but are reported as error rate next to the throughput. With
+// testConfig.Load.OpenLoop, samples are started at a fixed arrival rate instead, see runOpenLoop. The index passed to
+// the sampler is always below testConfig.Samples, so that samplers can use it for data prepared per sample; in load
+// mode with a duration, the indexes repeat.
+//
+// With testConfig.QueryStatistics, the number of SQL statements and the database time of an additional, unrecorded
+// sample after the experiment are recorded, see recordQueryStatistics. With testConfig.ExplainPlans, the plans of the
+// SQL statements executed during another unrecorded sample are captured, see explainStatements.
+func SampleExperiment(experiment *gmeasure.Experiment, testConfig Config, sampler func(idx int)) {
+	if testConfig.Load.Enabled() {
+		sampleLoad(experiment, testConfig, sampler)
+	} else {
+		experiment.Sample(sampler, gmeasure.SamplingConfig{N: testConfig.Samples})
+	}
+
+	if testConfig.QueryStatistics {
+		recordQueryStatistics(experiment, testConfig, sampler)
+	}
+	if testConfig.ExplainPlans {
+		explainStatements(experiment, testConfig, sampler)
+	}
+}
+
+func sampleLoad(experiment *gmeasure.Experiment, testConfig Config, sampler func(idx int)) {
+	run := &loadRun{}
+	activeLoadRun.Store(run)
+	defer activeLoadRun.Store(nil)
+
+	start := time.Now()
+	var samples int64
+	if testConfig.Load.OpenLoop {
+		samples = runOpenLoop(experiment, testConfig, sampler)
+	} else {
+		samples = runLoad(testConfig, sampler)
+	}
+	elapsed := time.Since(start)
+
+	// samplers that do not use TimeCCRequest are counted as one request per sample
+	requests := run.requests.Load()
+	if requests == 0 {
+		requests = samples
+	}
+	settings := gmeasure.Annotation(fmt.Sprintf("workers: %d, rate: %g, duration: %s, open loop: %t", testConfig.Load.Workers, testConfig.Load.Rate, testConfig.Load.Duration, testConfig.Load.OpenLoop))
+	experiment.RecordValue(ThroughputMeasurement, float64(requests)/elapsed.Seconds(), gmeasure.Units("Requests/Second"), settings)
+	experiment.RecordValue(ErrorRateMeasurement, float64(run.failures.Load())/float64(requests), gmeasure.Units("Ratio"))
+}
+
+func runLoad(testConfig Config, sampler func(idx int)) int64 {
+	load := testConfig.Load
+	workers := max(load.Workers, 1)
+
+	var deadline time.Time
+	if load.Duration > 0 {
+		deadline = time.Now().Add(load.Duration)
+	}
+
+	// the ticker drops ticks while all workers are busy, so the rate is an upper bound
+	var ticks <-chan time.Time
+	if load.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / load.Rate))
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	var next, completed atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer GinkgoRecover()
+			defer wg.Done()
+			for {
+				if ticks != nil {
+					<-ticks
+				}
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return
+				}
+				idx := next.Add(1) - 1
+				if deadline.IsZero() && idx >= int64(testConfig.Samples) {
+					return
+				}
+				sampler(int(idx % int64(testConfig.Samples)))
+				completed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return completed.Load()
+}
+
+// runOpenLoop starts a sample every 1/testConfig.Load.Rate seconds, regardless of how long earlier samples take,
+// so that a slow response does not delay the following requests (coordinated omission). If testConfig.Load.Workers
+// is set, at most that many samples run at the same time and further samples wait for a free worker. For every
+// sample, the delay between its intended and its actual start is recorded as "start delay", and the time from the
+// intended start until the sample completed as "corrected request time".
+func runOpenLoop(experiment *gmeasure.Experiment, testConfig Config, sampler func(idx int)) int64 {
+	load := testConfig.Load
+	interval := time.Duration(float64(time.Second) / load.Rate)
+
+	var workers chan struct{}
+	if load.Workers > 0 {
+		workers = make(chan struct{}, load.Workers)
+	}
+
+	var completed atomic.Int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	for idx := 0; ; idx++ {
+		intendedStart := start.Add(time.Duration(idx) * interval)
+		if load.Duration > 0 && !intendedStart.Before(start.Add(load.Duration)) {
+			break
+		}
+		if load.Duration == 0 && idx >= testConfig.Samples {
+			break
+		}
+		time.Sleep(time.Until(intendedStart))
+
+		wg.Add(1)
+		go func(idx int) {
+			defer GinkgoRecover()
+			defer wg.Done()
+			if workers != nil {
+				workers <- struct{}{}
+				defer func() { <-workers }()
+			}
+			experiment.RecordDuration(StartDelayMeasurement, time.Since(intendedStart))
+			sampler(idx)
+			experiment.RecordDuration(CorrectedRequestTimeMeasurement, time.Since(intendedStart))
+			completed.Add(1)
+		}(idx % testConfig.Samples)
+	}
+	wg.Wait()
+
+	return completed.Load()
+}
@@ -0,0 +1,263 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// the table recording the keys of all rows inserted by a Seeder, so that CleanupTestData deletes exactly these
+const manifestTable = "perf_cleanup_manifest"
+
+// manifestKeyColumns returns the columns identifying a row inserted with the given columns: the guid, or all
+// columns for join tables without guid.
+func manifestKeyColumns(columns []string) []int {
+	for i, column := range columns {
+		if column == "guid" {
+			return []int{i}
+		}
+	}
+	indexes := make([]int, len(columns))
+	for i := range columns {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// recordInserted adds the keys of rows about to be inserted into the table to the cleanup manifest.
+func (s *Seeder) recordInserted(table string, columns []string, rows [][]interface{}) {
+	if len(rows) == 0 {
+		return
+	}
+	keyColumnIndexes := manifestKeyColumns(columns)
+	keyColumns := make([]string, len(keyColumnIndexes))
+	for i, index := range keyColumnIndexes {
+		keyColumns[i] = columns[index]
+	}
+	keys := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		for _, index := range keyColumnIndexes {
+			keys[i] = append(keys[i], row[index])
+		}
+	}
+	s.recordKeys(table, keyColumns, keys)
+}
+
+// recordCopied adds the keys of all rows of the source table, which are about to be copied into the table, to the
+// cleanup manifest.
+func (s *Seeder) recordCopied(table string, keyColumns []string, sourceTable string) {
+	rows, err := s.db.QueryContext(s.ctx, fmt.Sprintf("SELECT %s FROM %s", strings.Join(keyColumns, ", "), sourceTable))
+	checkError(err)
+	defer rows.Close()
+
+	var keys [][]interface{}
+	for rows.Next() {
+		key := make([]interface{}, len(keyColumns))
+		pointers := make([]interface{}, len(keyColumns))
+		for i := range key {
+			pointers[i] = &key[i]
+		}
+		checkError(rows.Scan(pointers...))
+		for i, value := range key {
+			// MySQL returns all values as bytes
+			if bytes, ok := value.([]byte); ok {
+				key[i] = string(bytes)
+			}
+		}
+		keys = append(keys, key)
+	}
+	checkError(rows.Err())
+
+	for _, batch := range batches(keys, seedBatchSize) {
+		s.recordKeys(table, keyColumns, batch)
+	}
+}
+
+func (s *Seeder) recordKeys(table string, keyColumns []string, keys [][]interface{}) {
+	if !s.manifestCreated {
+		s.exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (table_name VARCHAR(255) NOT NULL, key_columns VARCHAR(255) NOT NULL, key_values TEXT NOT NULL)", manifestTable))
+		s.manifestCreated = true
+	}
+	keyValues, err := json.Marshal(keys)
+	checkError(err)
+	_, err = s.db.ExecContext(s.ctx, fmt.Sprintf("INSERT INTO %s (table_name, key_columns, key_values) VALUES (%s, %s, %s)", manifestTable, s.placeholder(1), s.placeholder(2), s.placeholder(3)),
+		table, strings.Join(keyColumns, ","), string(keyValues))
+	checkError(err)
+}
+
+type manifestEntry struct {
+	keyColumns string
+	keys       [][]interface{}
+}
+
+// deleteRecordedRows deletes the rows recorded in the cleanup manifest, ordered by the foreign keys between the
+// tables; the foreign keys in cleanupIgnoredForeignKeys must have been cleared before. Deletes failing because of rows not recorded in the manifest, e.g. created via the API, are returned to
+// be retried with retryRecordedRowDeletes once these rows are deleted.
+func deleteRecordedRows(db *sql.DB, ctx context.Context, testConfig Config) []string {
+	entries := map[string][]manifestEntry{}
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT table_name, key_columns, key_values FROM %s", manifestTable))
+	if err != nil {
+		log.Printf("No cleanup manifest found: %s", err.Error())
+		return nil
+	}
+	for rows.Next() {
+		var table, keyColumns, keyValues string
+		checkError(rows.Scan(&table, &keyColumns, &keyValues))
+		entry := manifestEntry{keyColumns: keyColumns}
+		// keep ids as numbers instead of converting them to floats
+		decoder := json.NewDecoder(strings.NewReader(keyValues))
+		decoder.UseNumber()
+		checkError(decoder.Decode(&entry.keys))
+		entries[table] = append(entries[table], entry)
+	}
+	checkError(rows.Err())
+	checkError(rows.Close())
+
+	log.Printf("%v Deleting rows recorded in the cleanup manifest...\n", time.Now().Format(time.RFC850))
+	var failed []string
+	for _, table := range tablesInDeleteOrder(db, ctx, testConfig, nil, cleanupIgnoredForeignKeys) {
+		for _, entry := range entries[table] {
+			statement := fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (%s)", table, entry.keyColumns, keyList(entry.keys))
+			if _, err := tryCleanupStatement(db, ctx, testConfig, statement); err != nil {
+				failed = append(failed, statement)
+			}
+		}
+		delete(entries, table)
+	}
+	for table := range entries {
+		log.Printf("Cannot delete rows of '%s' recorded in the cleanup manifest: table not found", table)
+	}
+	return failed
+}
+
+// retryRecordedRowDeletes retries the failed deletes of deleteRecordedRows, and reports the rows left behind if
+// they fail again. The manifest is emptied if all recorded rows are deleted.
+func retryRecordedRowDeletes(db *sql.DB, ctx context.Context, testConfig Config, failed []string) {
+	leftBehind := false
+	for _, statement := range failed {
+		if _, err := tryCleanupStatement(db, ctx, testConfig, statement); err != nil {
+			log.Printf("Rows recorded in the cleanup manifest left behind, '%s' failed: %s", statement, err.Error())
+			leftBehind = true
+		}
+	}
+	if !leftBehind {
+		executeCleanupStatement(db, ctx, testConfig, fmt.Sprintf("DELETE FROM %s", manifestTable))
+	}
+}
+
+// keyList formats the keys as SQL row values, e.g. ('a'), ('b') or (1, 2), (3, 4).
+func keyList(keys [][]interface{}) string {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		columns := make([]string, len(key))
+		for j, value := range key {
+			switch v := value.(type) {
+			case string:
+				columns[j] = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
+			case json.Number:
+				columns[j] = v.String()
+			default:
+				columns[j] = fmt.Sprint(v)
+			}
+		}
+		values[i] = fmt.Sprintf("(%s)", strings.Join(columns, ", "))
+	}
+	return strings.Join(values, ", ")
+}
+
+// tableReference is a foreign key, or a reference not declared as foreign key, from the child to the parent table.
+type tableReference struct {
+	child  string
+	parent string
+}
+
+// tablesInDeleteOrder returns the tables of the CCDB ordered so that every table comes before the tables it
+// references with foreign keys or with the additional references, see orderTablesForDelete. Foreign keys on the
+// ignored columns, given as "table.column", are not considered.
+func tablesInDeleteOrder(db *sql.DB, ctx context.Context, testConfig Config, references []tableReference, ignoredColumns []string) []string {
+	tablesQuery := "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'"
+	foreignKeysQuery := "SELECT child.table_name, child.column_name, parent.table_name FROM information_schema.referential_constraints AS rc " +
+		"JOIN information_schema.key_column_usage AS child ON child.constraint_schema = rc.constraint_schema AND child.constraint_name = rc.constraint_name " +
+		"JOIN information_schema.table_constraints AS parent ON parent.constraint_schema = rc.unique_constraint_schema AND parent.constraint_name = rc.unique_constraint_name " +
+		"WHERE rc.constraint_schema = current_schema()"
+	if testConfig.DatabaseType == MysqlDb {
+		tablesQuery = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'"
+		foreignKeysQuery = "SELECT table_name, column_name, referenced_table_name FROM information_schema.key_column_usage WHERE table_schema = DATABASE() AND referenced_table_name IS NOT NULL"
+	}
+
+	var tables []string
+	for _, table := range ExecuteSelectStatement(db, ctx, tablesQuery) {
+		tables = append(tables, ConvertToString(table))
+	}
+
+	ignored := map[string]bool{}
+	for _, column := range ignoredColumns {
+		ignored[column] = true
+	}
+	rows, err := db.QueryContext(ctx, foreignKeysQuery)
+	checkError(err)
+	for rows.Next() {
+		var child, column, parent string
+		checkError(rows.Scan(&child, &column, &parent))
+		if !ignored[child+"."+column] {
+			references = append(references, tableReference{child: child, parent: parent})
+		}
+	}
+	checkError(rows.Err())
+	checkError(rows.Close())
+	return orderTablesForDelete(tables, references)
+}
+
+// orderTablesForDelete orders the tables so that every table comes before the tables it references. References to
+// the table itself or to no table are not considered. Once only tables in cycles, or tables referenced from them, are
+// left, these are appended in alphabetical order.
+func orderTablesForDelete(tables []string, references []tableReference) []string {
+	tables = append([]string(nil), tables...)
+	sort.Strings(tables)
+
+	// other tables referencing each table, and the tables referenced by each table
+	referencingTables := map[string]map[string]bool{}
+	referencedTables := map[string][]string{}
+	for _, reference := range references {
+		if reference.child == reference.parent || reference.parent == "" {
+			continue
+		}
+		if referencingTables[reference.parent] == nil {
+			referencingTables[reference.parent] = map[string]bool{}
+		}
+		if !referencingTables[reference.parent][reference.child] {
+			referencingTables[reference.parent][reference.child] = true
+			referencedTables[reference.child] = append(referencedTables[reference.child], reference.parent)
+		}
+	}
+	var ordered []string
+	emitted := map[string]bool{}
+	for len(ordered) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if emitted[table] || len(referencingTables[table]) > 0 {
+				continue
+			}
+			ordered = append(ordered, table)
+			emitted[table] = true
+			progress = true
+			for _, parent := range referencedTables[table] {
+				delete(referencingTables[parent], table)
+			}
+		}
+		if !progress {
+			for _, table := range tables {
+				if !emitted[table] {
+					ordered = append(ordered, table)
+					emitted[table] = true
+				}
+			}
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderTablesForDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		tables     []string
+		references []tableReference
+		expected   []string
+	}{
+		{"no references", []string{"spaces", "apps", "organizations"}, nil, []string{"apps", "organizations", "spaces"}},
+		{
+			"chain",
+			[]string{"organizations", "spaces", "apps"},
+			[]tableReference{{"apps", "spaces"}, {"spaces", "organizations"}},
+			[]string{"apps", "spaces", "organizations"},
+		},
+		{
+			"table referenced by several tables",
+			[]string{"a", "b", "c"},
+			[]tableReference{{"b", "a"}, {"c", "a"}},
+			[]string{"b", "c", "a"},
+		},
+		{
+			"self references and references to no table are ignored",
+			[]string{"b", "a"},
+			[]tableReference{{"a", "a"}, {"a", ""}, {"a", "b"}},
+			[]string{"a", "b"},
+		},
+		{
+			"duplicate references",
+			[]string{"b", "a"},
+			[]tableReference{{"a", "b"}, {"a", "b"}},
+			[]string{"a", "b"},
+		},
+		{
+			"additional references to tables without foreign keys",
+			[]string{"apps", "droplets", "processes"},
+			[]tableReference{{"processes", "apps"}, {"apps", "droplets"}},
+			[]string{"processes", "apps", "droplets"},
+		},
+		{
+			"cycle falls back to alphabetical order",
+			[]string{"c", "b", "a"},
+			[]tableReference{{"a", "b"}, {"b", "c"}, {"c", "a"}},
+			[]string{"a", "b", "c"},
+		},
+		{
+			"tables outside of a cycle are ordered first",
+			[]string{"a", "b", "c", "z"},
+			[]tableReference{{"a", "b"}, {"b", "a"}, {"z", "a"}, {"c", "z"}},
+			[]string{"c", "z", "a", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := orderTablesForDelete(test.tables, test.references); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("orderTablesForDelete(%v, %v) = %v, expected %v", test.tables, test.references, actual, test.expected)
+			}
+		})
+	}
+}
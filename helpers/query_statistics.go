@@ -0,0 +1,110 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+// Names of the values recorded per sample with testConfig.QueryStatistics.
+const (
+	QueryCountMeasurement = "query count"
+	QueryTimeMeasurement  = "query time"
+)
+
+var QueryMeasurements = []string{QueryCountMeasurement, QueryTimeMeasurement}
+
+// queryTotals are the number of statements executed on the CCDB and their total execution time so far.
+type queryTotals struct {
+	count int64
+	time  time.Duration
+}
+
+// queryCounter sums the statements executed during the timed requests of the sample counting the queries, see
+// countQueries.
+type queryCounter struct {
+	ctx        context.Context
+	testConfig Config
+	totals     queryTotals
+	requests   int
+	err        error
+}
+
+var activeQueryCounter atomic.Pointer[queryCounter]
+
+// recordQueryStatistics runs an additional, unrecorded sample after the experiment and records the number of SQL
+// statements executed on the CCDB during its timed requests (see TimeCCRequestReturning), and the time the database
+// spent executing them, as "query count" and "query time". Reading the statistics takes database round trips of its
+// own, so the measured samples are left alone. They are taken from pg_stat_statements (PostgreSQL) or
+// performance_schema.events_statements_summary_by_digest (MySQL), so that they include all clients of the CCDB
+// while the request runs; the statements reading the statistics are excluded, and statements the tests execute
+// between the requests, e.g. selecting random resources, are not counted.
+func recordQueryStatistics(experiment *gmeasure.Experiment, testConfig Config, sampler func(idx int)) {
+	if ccdbConnection == nil {
+		log.Printf("Cannot count queries without a CCDB connection")
+		return
+	}
+
+	counter := &queryCounter{ctx: context.Background(), testConfig: testConfig}
+	withoutRecording(experiment, func() {
+		activeQueryCounter.Store(counter)
+		defer activeQueryCounter.Store(nil)
+		for _, failure := range InterceptGomegaFailures(func() { sampler(0) }) {
+			log.Printf("Sample counting the queries of '%s' failed: %s", experiment.Name, failure)
+		}
+	})
+
+	if counter.err != nil {
+		log.Printf("Cannot count queries of '%s': %s", experiment.Name, counter.err.Error())
+		return
+	}
+	if counter.requests == 0 {
+		return
+	}
+	experiment.RecordValue(QueryCountMeasurement, float64(counter.totals.count), gmeasure.Units("Queries"))
+	experiment.RecordDuration(QueryTimeMeasurement, counter.totals.time)
+}
+
+// countQueries runs the request and adds the statements executed meanwhile to the counter of the sample counting the
+// queries, if there is one.
+func countQueries(request func()) {
+	counter := activeQueryCounter.Load()
+	if counter == nil || counter.err != nil {
+		request()
+		return
+	}
+	before, err := readQueryTotals(counter.ctx, counter.testConfig)
+	request()
+	if err != nil {
+		counter.err = err
+		return
+	}
+	after, err := readQueryTotals(counter.ctx, counter.testConfig)
+	if err != nil {
+		counter.err = err
+		return
+	}
+	counter.totals.count += after.count - before.count
+	counter.totals.time += after.time - before.time
+	counter.requests++
+}
+
+func readQueryTotals(ctx context.Context, testConfig Config) (queryTotals, error) {
+	// total_exec_time is in milliseconds, SUM_TIMER_WAIT in picoseconds
+	query := "SELECT COALESCE(SUM(calls), 0), COALESCE(SUM(total_exec_time), 0) * 1000000 FROM pg_stat_statements " +
+		"WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database()) AND query NOT LIKE '%pg_stat_statements%'"
+	if testConfig.DatabaseType == MysqlDb {
+		query = "SELECT COALESCE(SUM(COUNT_STAR), 0), COALESCE(SUM(SUM_TIMER_WAIT), 0) / 1000 FROM performance_schema.events_statements_summary_by_digest " +
+			"WHERE SCHEMA_NAME = DATABASE() AND DIGEST_TEXT NOT LIKE '%performance_schema%'"
+	}
+
+	var totals queryTotals
+	var nanoseconds float64
+	err := ccdbConnection.QueryRowContext(ctx, query).Scan(&totals.count, &nanoseconds)
+	totals.time = time.Duration(nanoseconds)
+	return totals, err
+}
@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/cloudfoundry/cf-performance-tests/results"
+)
+
+// the server version of the CCDB read by OpenDbConnections
+var ccdbServerVersion string
+
+type RunMetadata = results.RunMetadata
+
+// collectRunMetadata gathers the metadata of the current run; values that cannot be determined are left empty.
+func collectRunMetadata(testConfig Config, testVersion string) RunMetadata {
+	metadata := RunMetadata{
+		GitCommit:       commandOutput("git", "rev-parse", "HEAD"),
+		TestVersion:     testVersion,
+		Samples:         testConfig.Samples,
+		DatabaseVersion: ccdbServerVersion,
+		CFCLIVersion:    commandOutput("cf", "version"),
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		log.Printf("Cannot determine the host name: %s", err.Error())
+	}
+	metadata.Host = host
+
+	client := NewCCClient(testConfig)
+	var root struct {
+		Links struct {
+			CloudControllerV2 struct {
+				Meta struct {
+					Version string `json:"version"`
+				} `json:"meta"`
+			} `json:"cloud_controller_v2"`
+			CloudControllerV3 struct {
+				Meta struct {
+					Version string `json:"version"`
+				} `json:"meta"`
+			} `json:"cloud_controller_v3"`
+		} `json:"links"`
+	}
+	if getAPIJson(client, "/", &root) {
+		metadata.CCAPIV2Version = root.Links.CloudControllerV2.Meta.Version
+		metadata.CCAPIV3Version = root.Links.CloudControllerV3.Meta.Version
+	}
+	var info struct {
+		Name    string `json:"name"`
+		Build   string `json:"build"`
+		Version int    `json:"version"`
+	}
+	if getAPIJson(client, "/v3/info", &info) {
+		metadata.InfoName = info.Name
+		metadata.InfoBuild = info.Build
+		metadata.InfoVersion = info.Version
+	}
+	return metadata
+}
+
+func getAPIJson(client *CCClient, path string, result interface{}) bool {
+	response, err := client.Do(context.Background(), http.MethodGet, path, nil, nil)
+	if err == nil && response.StatusCode != http.StatusOK {
+		log.Printf("Cannot read run metadata from %s: %s", path, response.Status)
+		return false
+	}
+	if err == nil {
+		err = json.Unmarshal(response.Body, result)
+	}
+	if err != nil {
+		log.Printf("Cannot read run metadata from %s: %s", path, err.Error())
+		return false
+	}
+	return true
+}
+
+// commandOutput returns the trimmed output of the command, or an empty string if it fails.
+func commandOutput(name string, args ...string) string {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		log.Printf("Cannot run '%s %s': %s", name, strings.Join(args, " "), err.Error())
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,105 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// the table marking a CCDB as belonging to a foundation used for performance tests
+const environmentMarkerTable = "perf_environment"
+
+// Safety configures the guards against seeding or cleaning up a CCDB that does not belong to a test foundation.
+type Safety struct {
+	// write the marker to a CCDB without one when seeding
+	MarkEnvironment bool `mapstructure:"mark_environment"`
+	// only log the rows the cleanup would delete
+	DryRun bool `mapstructure:"dry_run"`
+	// truncate the events table and run VACUUM FULL during cleanup and before the tests
+	DestructiveCleanup bool `mapstructure:"destructive_cleanup"`
+}
+
+// RequireTestEnvironment stops the test unless the CCDB is marked as belonging to the foundation of the configured
+// API. With testConfig.Safety.MarkEnvironment, a missing marker is written instead; this is only done when seeding.
+func RequireTestEnvironment(ccdb *sql.DB, ctx context.Context, testConfig Config, action string) {
+	if isMarkedTestEnvironment(ccdb, ctx, testConfig) {
+		return
+	}
+	if action == "seed" && testConfig.Safety.MarkEnvironment {
+		log.Printf("Marking the CCDB as test environment of %s", testConfig.API)
+		ExecuteStatement(ccdb, ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (api VARCHAR(255) NOT NULL PRIMARY KEY)", environmentMarkerTable))
+		_, err := ccdb.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (api) VALUES (%s)", environmentMarkerTable, sqlPlaceholder(testConfig, 1)), testConfig.API)
+		checkError(err)
+		return
+	}
+	log.Fatalf("refusing to %s: the CCDB is not marked as test environment of %s (table %s); if it belongs to a dedicated test foundation, set safety.mark_environment once",
+		action, testConfig.API, environmentMarkerTable)
+}
+
+func isMarkedTestEnvironment(ccdb *sql.DB, ctx context.Context, testConfig Config) bool {
+	var count int
+	// the query fails if the marker table does not exist
+	err := ccdb.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE api = %s", environmentMarkerTable, sqlPlaceholder(testConfig, 1)), testConfig.API).Scan(&count)
+	return err == nil && count > 0
+}
+
+// sqlPlaceholder returns the placeholder of the n-th statement argument for the configured database.
+func sqlPlaceholder(testConfig Config, n int) string {
+	if testConfig.DatabaseType == PsqlDb {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+var (
+	deletePattern   = regexp.MustCompile(`^DELETE FROM (\w+) WHERE (.*)$`)
+	updatePattern   = regexp.MustCompile(`^UPDATE (\w+) SET .* WHERE (.*)$`)
+	truncatePattern = regexp.MustCompile(`^TRUNCATE (\w+)$`)
+)
+
+// countStatement returns a query counting the rows the DELETE, UPDATE or TRUNCATE statement would affect, or an
+// empty string for other statements.
+func countStatement(statement string) string {
+	if match := deletePattern.FindStringSubmatch(statement); match != nil {
+		return fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", match[1], match[2])
+	}
+	if match := updatePattern.FindStringSubmatch(statement); match != nil {
+		return fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", match[1], match[2])
+	}
+	if match := truncatePattern.FindStringSubmatch(statement); match != nil {
+		return fmt.Sprintf("SELECT COUNT(*) FROM %s", match[1])
+	}
+	return ""
+}
+
+// executeCleanupStatement runs the statement, or logs the number of rows it would affect in dry-run mode. It returns
+// the number of affected rows.
+func executeCleanupStatement(db *sql.DB, ctx context.Context, testConfig Config, statement string) int64 {
+	rowsAffected, err := tryCleanupStatement(db, ctx, testConfig, statement)
+	checkError(err)
+	return rowsAffected
+}
+
+func tryCleanupStatement(db *sql.DB, ctx context.Context, testConfig Config, statement string) (int64, error) {
+	if !testConfig.Safety.DryRun {
+		result, err := db.ExecContext(ctx, statement)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected()
+	}
+	query := countStatement(statement)
+	if query == "" {
+		log.Printf("Dry run: would execute '%s'", statement)
+		return 0, nil
+	}
+	var rowsAffected int64
+	err := db.QueryRowContext(ctx, query).Scan(&rowsAffected)
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Dry run: %d rows affected by '%s'", rowsAffected, statement)
+	return rowsAffected, nil
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+// SeederMinSchemaVersion is the oldest CCDB schema version the Seeder is known to work with; it writes to
+// spaces_supporters, which was added to the CCDB in early 2021.
+const SeederMinSchemaVersion = "20210401000000"
+
+// the CCDB schema version read by OpenDbConnections, recorded in the JSON report
+var ccdbSchemaVersion string
+
+var migrationTimestampPattern = regexp.MustCompile(`^\d+`)
+
+// SchemaRange is the range of CCDB schema versions a suite supports. Versions are migration file names of the
+// Cloud Controller, e.g. "20210401000000_create_foo.rb", or their timestamps; both bounds are inclusive and
+// optional.
+type SchemaRange struct {
+	Min string
+	Max string
+}
+
+// readSchemaVersion returns the name of the latest migration applied to the CCDB.
+func readSchemaVersion(db *sql.DB, ctx context.Context) (string, error) {
+	var version sql.NullString
+	err := db.QueryRowContext(ctx, "SELECT MAX(filename) FROM schema_migrations").Scan(&version)
+	return version.String, err
+}
+
+// RequireSchemaVersion skips the suite if the CCDB schema version is outside the supported range. It is called in
+// BeforeSuite after OpenDbConnections and before seeding.
+func RequireSchemaVersion(supported SchemaRange) {
+	if ccdbSchemaVersion == "" {
+		log.Printf("CCDB schema version unknown, cannot check if it is supported")
+		return
+	}
+	version := migrationTimestamp(ccdbSchemaVersion)
+	if supported.Min != "" && version < migrationTimestamp(supported.Min) {
+		Skip(fmt.Sprintf("CCDB schema version %s is older than %s, the oldest version supported by this suite", ccdbSchemaVersion, supported.Min))
+	}
+	if supported.Max != "" && version > migrationTimestamp(supported.Max) {
+		Skip(fmt.Sprintf("CCDB schema version %s is newer than %s, the latest version supported by this suite", ccdbSchemaVersion, supported.Max))
+	}
+}
+
+// RequireSeederSchemaVersion skips the suite if the Seeder does not support the CCDB schema version, see
+// RequireSchemaVersion. Suites with further requirements on the schema call RequireSchemaVersion instead.
+func RequireSeederSchemaVersion() {
+	RequireSchemaVersion(SchemaRange{Min: SeederMinSchemaVersion})
+}
+
+// migrationTimestamp returns the timestamp prefix of a migration file name, padded to the 14 digits of
+// YYYYMMDDHHMMSS so that shorter bounds like "2021" can be compared.
+func migrationTimestamp(version string) string {
+	timestamp := migrationTimestampPattern.FindString(version)
+	for len(timestamp) < 14 {
+		timestamp += "0"
+	}
+	return timestamp
+}
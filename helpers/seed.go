@@ -0,0 +1,698 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tables assigning users to orgs and spaces, as used by AssignUserAsOrgRole and AssignUserAsSpaceRole.
+const (
+	OrganizationsManagers        = "organizations_managers"
+	OrganizationsBillingManagers = "organizations_billing_managers"
+	OrganizationsAuditors        = "organizations_auditors"
+	OrganizationsUsers           = "organizations_users"
+	SpacesManagers               = "spaces_managers"
+	SpacesDevelopers             = "spaces_developers"
+	SpacesSupporters             = "spaces_supporters"
+	SpacesAuditors               = "spaces_auditors"
+)
+
+const defaultQuotaDefinitionId = 1
+const defaultDomainId = 1
+
+// rows per INSERT statement; limited so that statements with large values (e.g. service plans with boilerplate)
+// stay well below the maximum packet size of MySQL
+const seedBatchSize = 500
+
+// both PostgreSQL and MySQL allow at most 65535 placeholders per statement
+const maxPlaceholders = 65535
+
+const securityGroupRules = `[
+    {
+        "protocol": "icmp",
+        "destination": "0.0.0.0/0",
+        "type": 0,
+        "code": 0
+    },
+    {
+        "protocol": "tcp",
+        "destination": "10.0.11.0/24",
+        "ports": "80,443",
+        "log": true,
+        "description": "Allow http and https traffic to ZoneA"
+    }
+]`
+
+// Seeder creates test data in the CCDB. The rows are generated in Go and written with multi-row inserts, so the
+// same entity graphs are created for both PostgreSQL and MySQL. All created resources are named with the test
+// resource prefix, so that CleanupTestData removes them.
+type Seeder struct {
+	db         *sql.DB
+	ctx        context.Context
+	testConfig Config
+	prefix     string
+
+	// whether the table selected_orgs was created, see Snapshot
+	selectedOrgs bool
+	// whether the cleanup manifest table was created, see recordKeys
+	manifestCreated bool
+}
+
+// NewSeeder stops the test unless the CCDB is marked as test environment, see RequireTestEnvironment.
+func NewSeeder(ccdb *sql.DB, ctx context.Context, testConfig Config) *Seeder {
+	RequireTestEnvironment(ccdb, ctx, testConfig, "seed")
+	return &Seeder{
+		db:         ccdb,
+		ctx:        ctx,
+		testConfig: testConfig,
+		prefix:     testConfig.GetNamePrefix(),
+	}
+}
+
+// CreateOrgs creates orgs with the default quota.
+func (s *Seeder) CreateOrgs(numOrgs int) {
+	defer s.logStep("create %d orgs", numOrgs)()
+
+	var rows [][]interface{}
+	for i := 0; i < numOrgs; i++ {
+		guid := uuid.NewString()
+		rows = append(rows, []interface{}{guid, s.name("org", guid), defaultQuotaDefinitionId})
+	}
+	s.insertRows("organizations", []string{"guid", "name", "quota_definition_id"}, rows)
+}
+
+// CreateSelectedOrgsTable (re-)creates the table selected_orgs containing the ids of numOrgs random orgs. Roles,
+// visibilities etc. are assigned for the selected orgs, and tests use the table to choose orgs visible to the
+// regular user.
+func (s *Seeder) CreateSelectedOrgsTable(numOrgs int) {
+	defer s.logStep("select %d orgs", numOrgs)()
+
+	s.exec("DROP TABLE IF EXISTS selected_orgs")
+	s.exec("CREATE TABLE selected_orgs(id INT NOT NULL PRIMARY KEY)")
+	s.exec(fmt.Sprintf("INSERT INTO selected_orgs (id) SELECT id FROM organizations WHERE name LIKE '%s' ORDER BY %s LIMIT %d",
+		s.nameQuery("org"), GetRandomFunction(s.testConfig), numOrgs))
+	s.selectedOrgs = true
+}
+
+// CreateSpaces creates numSpacesPerOrg spaces in every org, each with a label.
+func (s *Seeder) CreateSpaces(numSpacesPerOrg int) {
+	defer s.logStep("create %d spaces per org", numSpacesPerOrg)()
+
+	orgIds := s.selectIds(fmt.Sprintf("SELECT id FROM organizations WHERE name LIKE '%s'", s.nameQuery("org")))
+
+	var spaceRows, labelRows [][]interface{}
+	for i := 0; i < numSpacesPerOrg; i++ {
+		for _, orgId := range orgIds {
+			guid := uuid.NewString()
+			spaceRows = append(spaceRows, []interface{}{guid, s.name("space", uuid.NewString()), orgId})
+			labelRows = append(labelRows, []interface{}{guid, s.prefix, guid})
+		}
+	}
+	s.insertRows("spaces", []string{"guid", "name", "organization_id"}, spaceRows)
+	s.insertRows("space_labels", []string{"guid", "key_name", "resource_guid"}, labelRows)
+}
+
+// CreateSecurityGroups creates security groups with an ICMP and a TCP rule.
+func (s *Seeder) CreateSecurityGroups(numSecurityGroups int) {
+	defer s.logStep("create %d security groups", numSecurityGroups)()
+
+	var rows [][]interface{}
+	for i := 0; i < numSecurityGroups; i++ {
+		guid := uuid.NewString()
+		rows = append(rows, []interface{}{guid, s.name("security-group", guid), securityGroupRules})
+	}
+	s.insertRows("security_groups", []string{"guid", "name", "rules"}, rows)
+}
+
+// AssignSecurityGroupsToSpaces assigns numSecurityGroupsPerSpace random security groups to each of numSpaces random
+// spaces; a security group can be assigned to multiple spaces.
+func (s *Seeder) AssignSecurityGroupsToSpaces(numSpaces int, numSecurityGroupsPerSpace int) {
+	defer s.logStep("assign %d security groups to each of %d spaces", numSecurityGroupsPerSpace, numSpaces)()
+
+	spaceIds := s.selectIds(s.randomSelect("spaces", "id", "space", numSpaces))
+	securityGroupIds := s.selectIds(fmt.Sprintf("SELECT id FROM security_groups WHERE name LIKE '%s'", s.nameQuery("security-group")))
+
+	var rows [][]interface{}
+	for _, spaceId := range spaceIds {
+		for _, securityGroupId := range randomSubset(securityGroupIds, numSecurityGroupsPerSpace) {
+			rows = append(rows, []interface{}{securityGroupId, spaceId})
+		}
+	}
+	s.insertRows("security_groups_spaces", []string{"security_group_id", "space_id"}, rows)
+}
+
+// AssignUserAsOrgRole assigns the user the role (e.g. OrganizationsManagers) in numOrgs random selected orgs.
+func (s *Seeder) AssignUserAsOrgRole(userGuid string, orgRole string, numOrgs int) {
+	defer s.logStep("assign user as %s in %d orgs", orgRole, numOrgs)()
+
+	userId := s.userId(userGuid)
+	orgIds := s.selectIds(fmt.Sprintf("SELECT id FROM selected_orgs ORDER BY %s LIMIT %d", GetRandomFunction(s.testConfig), numOrgs))
+
+	var rows [][]interface{}
+	for _, orgId := range orgIds {
+		rows = append(rows, []interface{}{orgId, userId})
+	}
+	s.insertRows(orgRole, []string{"organization_id", "user_id"}, rows)
+}
+
+// AssignUserDisjointOrgRoles assigns the user one org role per disjoint quarter of the selected orgs. Each of the 4
+// org role tables receives an equal, non-overlapping slice, so the union of readable orgs equals exactly the
+// selected orgs on every run, while all 4 role tables are exercised.
+func (s *Seeder) AssignUserDisjointOrgRoles(userGuid string) {
+	defer s.logStep("assign user disjoint org roles")()
+
+	userId := s.userId(userGuid)
+	orgIds := s.selectIds("SELECT id FROM selected_orgs ORDER BY id")
+
+	orgRoles := []string{OrganizationsManagers, OrganizationsBillingManagers, OrganizationsAuditors, OrganizationsUsers}
+	rowsByRole := make([][][]interface{}, len(orgRoles))
+	for i, orgId := range orgIds {
+		rowsByRole[i%len(orgRoles)] = append(rowsByRole[i%len(orgRoles)], []interface{}{orgId, userId})
+	}
+	for i, orgRole := range orgRoles {
+		s.insertRows(orgRole, []string{"organization_id", "user_id"}, rowsByRole[i])
+	}
+}
+
+// AssignUserAsSpaceRole assigns the user the role (e.g. SpacesDevelopers) in numSpaces random spaces of the
+// selected orgs.
+func (s *Seeder) AssignUserAsSpaceRole(userGuid string, spaceRole string, numSpaces int) {
+	defer s.logStep("assign user as %s in %d spaces", spaceRole, numSpaces)()
+
+	userId := s.userId(userGuid)
+	spaceIds := s.selectIds(fmt.Sprintf("SELECT spaces.id FROM spaces JOIN selected_orgs ON spaces.organization_id = selected_orgs.id WHERE spaces.name LIKE '%s' ORDER BY %s LIMIT %d",
+		s.nameQuery("space"), GetRandomFunction(s.testConfig), numSpaces))
+
+	var rows [][]interface{}
+	for _, spaceId := range spaceIds {
+		rows = append(rows, []interface{}{spaceId, userId})
+	}
+	s.insertRows(spaceRole, []string{"space_id", "user_id"}, rows)
+}
+
+// CreateSharedDomains creates domains not owned by any org.
+func (s *Seeder) CreateSharedDomains(numSharedDomains int) {
+	defer s.logStep("create %d shared domains", numSharedDomains)()
+
+	var rows [][]interface{}
+	for i := 0; i < numSharedDomains; i++ {
+		guid := uuid.NewString()
+		rows = append(rows, []interface{}{guid, s.name("shared-domain", guid)})
+	}
+	s.insertRows("domains", []string{"guid", "name"}, rows)
+}
+
+// CreatePrivateDomains creates domains that are evenly assigned to random orgs.
+func (s *Seeder) CreatePrivateDomains(numPrivateDomains int) {
+	defer s.logStep("create %d private domains", numPrivateDomains)()
+
+	orgIds := s.selectIds(fmt.Sprintf("SELECT id FROM organizations WHERE name LIKE '%s'", s.nameQuery("org")))
+	if len(orgIds) == 0 {
+		log.Fatal("cannot create private domains without orgs")
+	}
+
+	var rows [][]interface{}
+	for len(rows) < numPrivateDomains {
+		for _, orgId := range randomSubset(orgIds, numPrivateDomains-len(rows)) {
+			guid := uuid.NewString()
+			rows = append(rows, []interface{}{guid, s.name("private-domain", guid), orgId})
+		}
+	}
+	s.insertRows("domains", []string{"guid", "name", "owning_organization_id"}, rows)
+}
+
+// CreateIsolationSegments creates isolation segments without any orgs.
+func (s *Seeder) CreateIsolationSegments(numIsolationSegments int) {
+	defer s.logStep("create %d isolation segments", numIsolationSegments)()
+
+	var rows [][]interface{}
+	for i := 0; i < numIsolationSegments; i++ {
+		guid := uuid.NewString()
+		rows = append(rows, []interface{}{guid, s.name("isolation-segment", guid)})
+	}
+	s.insertRows("isolation_segments", []string{"guid", "name"}, rows)
+}
+
+// AssignOrgsToIsolationSegments assigns numOrgs random orgs to a random isolation segment each.
+func (s *Seeder) AssignOrgsToIsolationSegments(numOrgs int) {
+	defer s.logStep("assign %d orgs to isolation segments", numOrgs)()
+
+	orgGuids := s.selectGuids(s.randomSelect("organizations", "guid", "org", numOrgs))
+	isolationSegmentGuids := s.selectGuids(fmt.Sprintf("SELECT guid FROM isolation_segments WHERE name LIKE '%s'", s.nameQuery("isolation-segment")))
+	if len(isolationSegmentGuids) == 0 {
+		log.Fatal("cannot assign orgs to isolation segments without isolation segments")
+	}
+
+	var rows [][]interface{}
+	for _, orgGuid := range orgGuids {
+		rows = append(rows, []interface{}{orgGuid, isolationSegmentGuids[rand.IntN(len(isolationSegmentGuids))]})
+	}
+	s.insertRows("organizations_isolation_segments", []string{"organization_guid", "isolation_segment_guid"}, rows)
+}
+
+// CreateOrgQuotasAndDistributeOrgs creates org quotas without limits and assigns the orgs to them round-robin.
+func (s *Seeder) CreateOrgQuotasAndDistributeOrgs(numQuotas int) {
+	defer s.logStep("create %d org quotas", numQuotas)()
+
+	var rows [][]interface{}
+	for i := 0; i < numQuotas; i++ {
+		rows = append(rows, []interface{}{uuid.NewString(), s.name("org-quota", uuid.NewString()), true, -1, -1, -1})
+	}
+	s.insertRows("quota_definitions", []string{"guid", "name", "non_basic_services_allowed", "total_services", "memory_limit", "total_routes"}, rows)
+
+	quotaIds := s.selectIds(fmt.Sprintf("SELECT id FROM quota_definitions WHERE name LIKE '%s' ORDER BY id", s.nameQuery("org-quota")))
+	orgIds := s.selectIds(fmt.Sprintf("SELECT id FROM organizations WHERE name LIKE '%s' ORDER BY id", s.nameQuery("org")))
+
+	orgIdsByQuota := make([][]int, len(quotaIds))
+	for i, orgId := range orgIds {
+		orgIdsByQuota[i%len(quotaIds)] = append(orgIdsByQuota[i%len(quotaIds)], orgId)
+	}
+	for i, quotaId := range quotaIds {
+		for _, batch := range batches(orgIdsByQuota[i], seedBatchSize) {
+			s.exec(fmt.Sprintf("UPDATE organizations SET quota_definition_id = %d WHERE id IN (%s)", quotaId, joinInts(batch)))
+		}
+	}
+}
+
+// CreateServicesAndPlans creates numServices services of the given broker with numServicePlans plans each. Every
+// plan is made visible in visibleOrgsPerPlan random selected orgs. With boilerplate, the plans have descriptions and
+// schemas of about 4KB.
+func (s *Seeder) CreateServicesAndPlans(numServices int, serviceBrokerId int, numServicePlans int, servicePlanPublic bool, visibleOrgsPerPlan int, withBoilerplate bool) {
+	defer s.logStep("create %d services with %d plans each", numServices, numServicePlans)()
+
+	boilerplate := ""
+	if withBoilerplate {
+		boilerplate = servicePlanBoilerplate
+	}
+
+	var serviceRows [][]interface{}
+	var serviceGuids []string
+	for i := 0; i < numServices; i++ {
+		guid := uuid.NewString()
+		serviceGuids = append(serviceGuids, guid)
+		serviceRows = append(serviceRows, []interface{}{guid, s.name("service", guid), s.name("service-description", guid), true, serviceBrokerId, `{"shareable": true}`})
+	}
+	s.insertRows("services", []string{"guid", "label", "description", "bindable", "service_broker_id", "extra"}, serviceRows)
+	serviceIds := s.idsByGuid("services", serviceGuids)
+
+	var planRows [][]interface{}
+	var planGuids []string
+	for _, serviceGuid := range serviceGuids {
+		for i := 0; i < numServicePlans; i++ {
+			guid := uuid.NewString()
+			planGuids = append(planGuids, guid)
+			planRows = append(planRows, []interface{}{guid, s.name("service-plan", guid), s.name("service-plan-description", guid) + boilerplate, true,
+				serviceIds[serviceGuid], "unique-" + guid, servicePlanPublic, `{"shareable": true}`, boilerplate, boilerplate, boilerplate})
+		}
+	}
+	s.insertRows("service_plans", []string{"guid", "name", "description", "free", "service_id", "unique_id", "public", "extra",
+		"create_instance_schema", "update_instance_schema", "create_binding_schema"}, planRows)
+
+	if visibleOrgsPerPlan == 0 {
+		return
+	}
+	planIds := s.idsByGuid("service_plans", planGuids)
+	selectedOrgIds := s.selectIds("SELECT id FROM selected_orgs")
+	var visibilityRows [][]interface{}
+	for _, planGuid := range planGuids {
+		for _, orgId := range randomSubset(selectedOrgIds, visibleOrgsPerPlan) {
+			visibilityRows = append(visibilityRows, []interface{}{uuid.NewString(), planIds[planGuid], orgId})
+		}
+	}
+	s.insertRows("service_plan_visibilities", []string{"guid", "service_plan_id", "organization_id"}, visibilityRows)
+}
+
+// CreateServiceInstances creates service instances of the given plan in the given space.
+func (s *Seeder) CreateServiceInstances(spaceId int, servicePlanId int, numServiceInstances int) {
+	defer s.logStep("create %d service instances in space %d", numServiceInstances, spaceId)()
+
+	s.insertRows("service_instances", []string{"guid", "name", "space_id", "service_plan_id"}, s.serviceInstanceRows(spaceId, servicePlanId, numServiceInstances))
+}
+
+// CreateServiceBroker creates a service broker without URL and credentials and returns its id.
+func (s *Seeder) CreateServiceBroker() int {
+	guid := uuid.NewString()
+	s.insertRows("service_brokers", []string{"guid", "name", "broker_url", "auth_password"}, [][]interface{}{{guid, s.name("service-broker", guid), "", ""}})
+	return s.idsByGuid("service_brokers", []string{guid})[guid]
+}
+
+// CreateServiceInstancesForRandomVisiblePlan creates service instances of a random plan that is visible in one of the
+// selected orgs, in a random space where the plan is visible.
+func (s *Seeder) CreateServiceInstancesForRandomVisiblePlan(numServiceInstances int) {
+	servicePlanId := s.selectIds(fmt.Sprintf("SELECT s_p_v.service_plan_id FROM service_plan_visibilities AS s_p_v JOIN selected_orgs AS s_o ON s_p_v.organization_id = s_o.id ORDER BY %s LIMIT 1", GetRandomFunction(s.testConfig)))[0]
+	spaceId := s.selectIds(fmt.Sprintf("SELECT spaces.id FROM spaces JOIN service_plan_visibilities AS s_p_v ON spaces.organization_id = s_p_v.organization_id WHERE s_p_v.service_plan_id = %d ORDER BY %s LIMIT 1", servicePlanId, GetRandomFunction(s.testConfig)))[0]
+	s.CreateServiceInstances(spaceId, servicePlanId, numServiceInstances)
+}
+
+// CreateServiceInstancesForOrgsSpacesPlans creates instancesPerPlanPerSpace service instances of each of the first
+// servicePlans plans in each of the first orgs * spacesPerOrg spaces.
+func (s *Seeder) CreateServiceInstancesForOrgsSpacesPlans(orgs int, spacesPerOrg int, servicePlans int, instancesPerPlanPerSpace int) {
+	defer s.logStep("create %d service instances per plan in %d spaces", instancesPerPlanPerSpace, orgs*spacesPerOrg)()
+
+	spaceIds := s.selectIds(fmt.Sprintf("SELECT id FROM spaces WHERE name LIKE '%s' ORDER BY id LIMIT %d", s.nameQuery("space"), orgs*spacesPerOrg))
+	servicePlanIds := s.selectIds(fmt.Sprintf("SELECT id FROM service_plans WHERE name LIKE '%s' ORDER BY id LIMIT %d", s.nameQuery("service-plan"), servicePlans))
+
+	var rows [][]interface{}
+	for _, spaceId := range spaceIds {
+		for _, servicePlanId := range servicePlanIds {
+			rows = append(rows, s.serviceInstanceRows(spaceId, servicePlanId, instancesPerPlanPerSpace)...)
+		}
+	}
+	s.insertRows("service_instances", []string{"guid", "name", "space_id", "service_plan_id"}, rows)
+}
+
+// CreateServiceInstanceShares shares sharesPerSpace service instances of each of the first orgs * spacesPerOrg
+// spaces with a random other space.
+func (s *Seeder) CreateServiceInstanceShares(orgs int, spacesPerOrg int, sharesPerSpace int) {
+	defer s.logStep("create %d service instance shares in %d spaces", sharesPerSpace, orgs*spacesPerOrg)()
+
+	allSpaces := s.selectIdsAndGuids(fmt.Sprintf("SELECT id, guid FROM spaces WHERE name LIKE '%s' ORDER BY id", s.nameQuery("space")))
+	if len(allSpaces) < 2 {
+		log.Fatal("cannot share service instances with less than 2 spaces")
+	}
+	spaces := allSpaces[:min(orgs*spacesPerOrg, len(allSpaces))]
+
+	var rows [][]interface{}
+	for _, space := range spaces {
+		serviceInstanceGuids := s.selectGuids(fmt.Sprintf("SELECT guid FROM service_instances WHERE space_id = %d AND name LIKE '%s' ORDER BY id LIMIT %d",
+			space.id, s.nameQuery("service-instance"), sharesPerSpace))
+		for _, serviceInstanceGuid := range serviceInstanceGuids {
+			targetSpace := allSpaces[rand.IntN(len(allSpaces))]
+			for targetSpace.id == space.id {
+				targetSpace = allSpaces[rand.IntN(len(allSpaces))]
+			}
+			rows = append(rows, []interface{}{serviceInstanceGuid, targetSpace.guid})
+		}
+	}
+	s.insertRows("service_instance_shares", []string{"service_instance_guid", "target_space_guid"}, rows)
+}
+
+// CreateServiceKeysForServiceInstances creates numServiceKeysPerServiceInstance keys for every service instance in
+// the given space.
+func (s *Seeder) CreateServiceKeysForServiceInstances(spaceId int, numServiceKeysPerServiceInstance int) {
+	defer s.logStep("create %d service keys per service instance in space %d", numServiceKeysPerServiceInstance, spaceId)()
+
+	serviceInstanceIds := s.selectIds(fmt.Sprintf("SELECT id FROM service_instances WHERE space_id = %d", spaceId))
+
+	var rows [][]interface{}
+	for _, serviceInstanceId := range serviceInstanceIds {
+		for i := 0; i < numServiceKeysPerServiceInstance; i++ {
+			guid := uuid.NewString()
+			rows = append(rows, []interface{}{guid, s.name("service-key", guid), "", serviceInstanceId})
+		}
+	}
+	s.insertRows("service_keys", []string{"guid", "name", "credentials", "service_instance_id"}, rows)
+}
+
+// CreateAuditEvents creates about 1.1 million events of more than 100 event types, each for a random org and
+// space.
+func (s *Seeder) CreateAuditEvents() {
+	defer s.logStep("create audit events")()
+
+	orgGuids := s.selectGuids(fmt.Sprintf("SELECT guid FROM organizations WHERE name LIKE '%s'", s.nameQuery("org")))
+	spaceGuids := s.selectGuids(fmt.Sprintf("SELECT guid FROM spaces WHERE name LIKE '%s'", s.nameQuery("space")))
+	if len(orgGuids) == 0 || len(spaceGuids) == 0 {
+		log.Fatal("cannot create audit events without orgs and spaces")
+	}
+
+	// the events are inserted per event type to keep the generated rows in memory small
+	timestamp := time.Now()
+	for _, auditEventType := range auditEventTypes {
+		var rows [][]interface{}
+		for i := 0; i < auditEventType.count; i++ {
+			guid := uuid.NewString()
+			rows = append(rows, []interface{}{guid, timestamp, auditEventType.eventType, s.name("events-actor", guid), s.name("events-actor-type", guid),
+				s.name("events-actee", guid), s.name("events-actee-type", guid), orgGuids[rand.IntN(len(orgGuids))], spaceGuids[rand.IntN(len(spaceGuids))]})
+		}
+		s.insertRows("events", []string{"guid", "timestamp", "type", "actor", "actor_type", "actee", "actee_type", "organization_guid", "space_guid"}, rows)
+	}
+}
+
+// CreateUsersWithOrgAndSpaceRoles creates an org and a space with the given guids, and numUsers users having all
+// org roles in the org and all space roles in the space.
+func (s *Seeder) CreateUsersWithOrgAndSpaceRoles(orgGuid string, spaceGuid string, numUsers int) {
+	defer s.logStep("create %d users with org and space roles", numUsers)()
+
+	s.insertRows("organizations", []string{"guid", "name", "quota_definition_id"}, [][]interface{}{{orgGuid, s.name("org", orgGuid), defaultQuotaDefinitionId}})
+	orgId := s.idsByGuid("organizations", []string{orgGuid})[orgGuid]
+	s.insertRows("spaces", []string{"guid", "name", "organization_id"}, [][]interface{}{{spaceGuid, s.name("space", spaceGuid), orgId}})
+	spaceId := s.idsByGuid("spaces", []string{spaceGuid})[spaceGuid]
+
+	var userRows [][]interface{}
+	var userGuids []string
+	for i := 0; i < numUsers; i++ {
+		guid := uuid.NewString()
+		userGuids = append(userGuids, guid)
+		userRows = append(userRows, []interface{}{guid, spaceId, true})
+	}
+	s.insertRows("users", []string{"guid", "default_space_id", "active"}, userRows)
+	userIds := s.idsByGuid("users", userGuids)
+
+	var orgRoleRows, spaceRoleRows [][]interface{}
+	for _, userGuid := range userGuids {
+		orgRoleRows = append(orgRoleRows, []interface{}{orgId, userIds[userGuid]})
+		spaceRoleRows = append(spaceRoleRows, []interface{}{spaceId, userIds[userGuid]})
+	}
+	for _, orgRole := range []string{OrganizationsManagers, OrganizationsBillingManagers, OrganizationsAuditors, OrganizationsUsers} {
+		s.insertRows(orgRole, []string{"organization_id", "user_id"}, orgRoleRows)
+	}
+	for _, spaceRole := range []string{SpacesManagers, SpacesDevelopers, SpacesSupporters, SpacesAuditors} {
+		s.insertRows(spaceRole, []string{"space_id", "user_id"}, spaceRoleRows)
+	}
+}
+
+// CreateRoutesAndRouteMappingsForApp creates routes on the default domain in the given space and maps them to the
+// app. The route quota of the org is lifted first.
+func (s *Seeder) CreateRoutesAndRouteMappingsForApp(appGuid string, orgName string, spaceGuid string, numRouteMappings int) {
+	defer s.logStep("create %d route mappings for app %s", numRouteMappings, appGuid)()
+
+	quotaId := s.selectIds(fmt.Sprintf("SELECT quota_definition_id FROM organizations WHERE name = '%s'", orgName))
+	if len(quotaId) != 1 {
+		log.Fatalf("cannot find org '%s'", orgName)
+	}
+	s.exec(fmt.Sprintf("UPDATE quota_definitions SET total_routes = -1 WHERE id = %d", quotaId[0]))
+	spaceId := s.idsByGuid("spaces", []string{spaceGuid})[spaceGuid]
+
+	var routeRows, routeMappingRows [][]interface{}
+	for i := 0; i < numRouteMappings; i++ {
+		routeGuid := uuid.NewString()
+		// shorten guid to be able to map more routes to the app (diego limitation)
+		routeRows = append(routeRows, []interface{}{routeGuid, defaultDomainId, spaceId, fmt.Sprintf("%s-%s", s.prefix, routeGuid[:13])})
+		routeMappingRows = append(routeMappingRows, []interface{}{uuid.NewString(), appGuid, routeGuid, "web"})
+	}
+	s.insertRows("routes", []string{"guid", "domain_id", "space_id", "host"}, routeRows)
+	s.insertRows("route_mappings", []string{"guid", "app_guid", "route_guid", "process_type"}, routeMappingRows)
+}
+
+// appLabelValues are the values of the label "env" of the apps created by CreateApps, assigned round-robin.
+var appLabelValues = []string{"production", "staging", "development", "test"}
+
+// CreateApps creates numAppsPerSpace stopped buildpack apps in every space, each with a web process, a package, a
+// staged current droplet and the labels "env" (see appLabelValues) and the resource prefix.
+func (s *Seeder) CreateApps(numAppsPerSpace int) {
+	defer s.logStep("create %d apps per space", numAppsPerSpace)()
+
+	spaceGuids := s.selectGuids(fmt.Sprintf("SELECT guid FROM spaces WHERE name LIKE '%s'", s.nameQuery("space")))
+
+	var appRows, lifecycleRows, processRows, packageRows, dropletRows, labelRows [][]interface{}
+	for i := 0; i < numAppsPerSpace; i++ {
+		for j, spaceGuid := range spaceGuids {
+			guid := uuid.NewString()
+			packageGuid := uuid.NewString()
+			appRows = append(appRows, []interface{}{guid, s.name("app", guid), spaceGuid, "STOPPED"})
+			lifecycleRows = append(lifecycleRows, []interface{}{uuid.NewString(), guid, "cflinuxfs4"})
+			processRows = append(processRows, []interface{}{uuid.NewString(), guid, "web", 1, 256, 1024, "STOPPED"})
+			packageRows = append(packageRows, []interface{}{packageGuid, guid, "bits", "READY"})
+			dropletRows = append(dropletRows, []interface{}{uuid.NewString(), guid, packageGuid, "STAGED"})
+			labelRows = append(labelRows,
+				[]interface{}{uuid.NewString(), "env", appLabelValues[(i*len(spaceGuids)+j)%len(appLabelValues)], guid},
+				[]interface{}{uuid.NewString(), s.prefix, "", guid})
+		}
+	}
+	s.insertRows("apps", []string{"guid", "name", "space_guid", "desired_state"}, appRows)
+	s.insertRows("buildpack_lifecycle_data", []string{"guid", "app_guid", "stack"}, lifecycleRows)
+	s.insertRows("processes", []string{"guid", "app_guid", "type", "instances", "memory", "disk_quota", "state"}, processRows)
+	s.insertRows("packages", []string{"guid", "app_guid", "type", "state"}, packageRows)
+	s.insertRows("droplets", []string{"guid", "app_guid", "package_guid", "state"}, dropletRows)
+	s.insertRows("app_labels", []string{"guid", "key_name", "value", "resource_guid"}, labelRows)
+	s.assignAppDroplets()
+}
+
+// assignAppDroplets sets the droplet of every app as its current droplet. This is done after creating the droplets, as
+// apps and droplets reference each other.
+func (s *Seeder) assignAppDroplets() {
+	s.exec(fmt.Sprintf("UPDATE apps SET droplet_guid = (SELECT guid FROM droplets WHERE droplets.app_guid = apps.guid) WHERE name LIKE '%s'",
+		s.nameQuery("app")))
+}
+
+func (s *Seeder) serviceInstanceRows(spaceId int, servicePlanId int, numServiceInstances int) [][]interface{} {
+	var rows [][]interface{}
+	for i := 0; i < numServiceInstances; i++ {
+		guid := uuid.NewString()
+		rows = append(rows, []interface{}{guid, s.name("service-instance", guid), spaceId, servicePlanId})
+	}
+	return rows
+}
+
+// insertRows writes the rows with as few multi-row INSERT statements as possible, and records them in the cleanup
+// manifest.
+func (s *Seeder) insertRows(table string, columns []string, rows [][]interface{}) {
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = s.quoteIdentifier(column)
+	}
+
+	batchSize := max(1, min(seedBatchSize, maxPlaceholders/len(columns)))
+	for _, batch := range batches(rows, batchSize) {
+		var statement strings.Builder
+		fmt.Fprintf(&statement, "INSERT INTO %s (%s) VALUES ", table, strings.Join(quotedColumns, ", "))
+		args := make([]interface{}, 0, len(batch)*len(columns))
+		for i, row := range batch {
+			if i > 0 {
+				statement.WriteString(", ")
+			}
+			placeholders := make([]string, len(row))
+			for j, value := range row {
+				args = append(args, value)
+				placeholders[j] = s.placeholder(len(args))
+			}
+			fmt.Fprintf(&statement, "(%s)", strings.Join(placeholders, ", "))
+		}
+		s.recordInserted(table, columns, batch)
+		_, err := s.db.ExecContext(s.ctx, statement.String(), args...)
+		checkError(err)
+	}
+}
+
+// idsByGuid returns the ids of the rows with the given guids.
+func (s *Seeder) idsByGuid(table string, guids []string) map[string]int {
+	ids := make(map[string]int, len(guids))
+	for _, batch := range batches(guids, seedBatchSize) {
+		query := fmt.Sprintf("SELECT id, guid FROM %s WHERE guid IN ('%s')", table, strings.Join(batch, "', '"))
+		for _, row := range s.selectIdsAndGuids(query) {
+			ids[row.guid] = row.id
+		}
+	}
+	for _, guid := range guids {
+		if _, found := ids[guid]; !found {
+			log.Fatalf("cannot find %s with guid '%s'", table, guid)
+		}
+	}
+	return ids
+}
+
+func (s *Seeder) userId(userGuid string) int {
+	return s.idsByGuid("users", []string{userGuid})[userGuid]
+}
+
+func (s *Seeder) selectIds(query string) []int {
+	return selectColumn[int](s, query)
+}
+
+func (s *Seeder) selectGuids(query string) []string {
+	return selectColumn[string](s, query)
+}
+
+type idAndGuid struct {
+	id   int
+	guid string
+}
+
+func (s *Seeder) selectIdsAndGuids(query string) []idAndGuid {
+	rows, err := s.db.QueryContext(s.ctx, query)
+	checkError(err)
+	defer rows.Close()
+
+	var results []idAndGuid
+	for rows.Next() {
+		var result idAndGuid
+		checkError(rows.Scan(&result.id, &result.guid))
+		results = append(results, result)
+	}
+	checkError(rows.Err())
+	return results
+}
+
+func selectColumn[T any](s *Seeder, query string) []T {
+	rows, err := s.db.QueryContext(s.ctx, query)
+	checkError(err)
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		var result T
+		checkError(rows.Scan(&result))
+		results = append(results, result)
+	}
+	checkError(rows.Err())
+	return results
+}
+
+// randomSelect returns a query for the given column of limit random rows named with the resource prefix.
+func (s *Seeder) randomSelect(table string, column string, resource string, limit int) string {
+	return fmt.Sprintf("SELECT %s FROM %s WHERE name LIKE '%s' ORDER BY %s LIMIT %d", column, table, s.nameQuery(resource), GetRandomFunction(s.testConfig), limit)
+}
+
+func (s *Seeder) exec(statement string) {
+	ExecuteStatement(s.db, s.ctx, statement)
+}
+
+func (s *Seeder) name(resource string, guid string) string {
+	return fmt.Sprintf("%s-%s-%s", s.prefix, resource, guid)
+}
+
+func (s *Seeder) nameQuery(resource string) string {
+	return fmt.Sprintf("%s-%s-%%", s.prefix, resource)
+}
+
+func (s *Seeder) placeholder(n int) string {
+	return sqlPlaceholder(s.testConfig, n)
+}
+
+func (s *Seeder) quoteIdentifier(identifier string) string {
+	if s.testConfig.DatabaseType == PsqlDb {
+		return fmt.Sprintf(`"%s"`, identifier)
+	}
+	return fmt.Sprintf("`%s`", identifier)
+}
+
+// logStep logs the start of a seeding step; the returned function logs its end.
+func (s *Seeder) logStep(format string, args ...interface{}) func() {
+	step := fmt.Sprintf(format, args...)
+	log.Printf("Seeding: %s...", step)
+	start := time.Now()
+	return func() {
+		log.Printf("Seeding: %s took %s", step, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// randomSubset returns n random elements of values, or all of them in random order if there are less than n.
+func randomSubset[T any](values []T, n int) []T {
+	shuffled := make([]T, len(values))
+	copy(shuffled, values)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled[:min(n, len(shuffled))]
+}
+
+func batches[T any](values []T, size int) [][]T {
+	var result [][]T
+	for start := 0; start < len(values); start += size {
+		result = append(result, values[start:min(start+size, len(values))])
+	}
+	return result
+}
+
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = fmt.Sprint(value)
+	}
+	return strings.Join(strs, ", ")
+}
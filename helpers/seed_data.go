@@ -0,0 +1,119 @@
+package helpers
+
+// auditEventTypes is the number of events per event type created by CreateAuditEvents.
+var auditEventTypes = []struct {
+	eventType string
+	count     int
+}{
+	{"audit.user.space_developer_add", 100000},
+	{"audit.app.environment_variables.show", 100000},
+	{"audit.service_binding.delete", 100000},
+	{"audit.user.organization_manager_remove", 50000},
+	{"audit.user.organization_billing_manager_remove", 50000},
+	{"audit.service_binding.create", 50000},
+	{"audit.service_instance.start_delete", 50000},
+	{"audit.service_plan.update", 50000},
+	{"audit.app.environment.show", 50000},
+	{"audit.app.map-route", 50000},
+	{"audit.app.unmap-route", 50000},
+	{"audit.user.space_supporter_add", 10000},
+	{"audit.app.process.crash", 10000},
+	{"app.crash", 10000},
+	{"audit.user.space_auditor_remove", 10000},
+	{"audit.user.organization_manager_add", 10000},
+	{"audit.user.space_manager_add", 10000},
+	{"audit.user.space_supporter_remove", 10000},
+	{"audit.app.build.create", 10000},
+	{"audit.app.droplet.create", 10000},
+	{"audit.app.process.update", 10000},
+	{"audit.app.process.scale", 10000},
+	{"audit.app.revision.create", 10000},
+	{"audit.app.stop", 10000},
+	{"audit.app.start", 10000},
+	{"audit.service.update", 10000},
+	{"audit.app.droplet.mapped", 10000},
+	{"audit.app.package.create", 10000},
+	{"audit.app.package.upload", 10000},
+	{"audit.app.process.rescheduling", 10000},
+	{"audit.route.create", 10000},
+	{"audit.app.update", 10000},
+	{"audit.app.package.delete", 10000},
+	{"audit.app.droplet.delete", 10000},
+	{"audit.service_broker.update", 10000},
+	{"audit.app.process.delete", 10000},
+	{"audit.user.space_manager_remove", 10000},
+	{"audit.route.delete-request", 10000},
+	{"audit.app.create", 10000},
+	{"audit.app.delete-request", 10000},
+	{"audit.service_instance.delete", 10000},
+	{"audit.service_instance.create", 10000},
+	{"audit.service_instance.update", 10000},
+	{"audit.app.process.create", 10000},
+	{"audit.app.ssh-authorized", 5000},
+	{"audit.user.organization_auditor_add", 5000},
+	{"audit.service_plan_visibility.update", 5000},
+	{"audit.service_key.create", 5000},
+	{"audit.user.organization_user_add", 5000},
+	{"audit.service_key.delete", 5000},
+	{"audit.service_instance.start_create", 5000},
+	{"audit.app.apply_manifest", 5000},
+	{"audit.user.space_auditor_add", 5000},
+	{"audit.user.space_developer_remove", 5000},
+	{"audit.user.organization_auditor_remove", 5000},
+	{"audit.user.organization_user_remove", 5000},
+	{"audit.app.restart", 1000},
+	{"audit.service_plan.create", 1000},
+	{"audit.service_plan.delete", 1000},
+	{"audit.service_plan_visibility.delete", 1000},
+	{"audit.app.upload-bits", 1000},
+	{"audit.app.task.create", 1000},
+	{"audit.user_provided_service_instance.update", 1000},
+	{"audit.service_instance.start_update", 1000},
+	{"audit.app.deployment.create", 1000},
+	{"audit.space.create", 1000},
+	{"audit.space.delete-request", 1000},
+	{"audit.organization.update", 500},
+	{"audit.user_provided_service_instance.create", 500},
+	{"audit.user_provided_service_instance.delete", 500},
+	{"audit.service_instance.unbind_route", 500},
+	{"audit.service_instance.bind_route", 500},
+	{"audit.app.restage", 500},
+	{"audit.route.update", 500},
+	{"audit.service.create", 100},
+	{"audit.service.delete", 100},
+	{"audit.service_broker.create", 100},
+	{"audit.service_broker.delete", 100},
+	{"audit.service_instance.purge", 100},
+	{"audit.organization.delete-request", 100},
+	{"audit.app.package.download", 100},
+	{"audit.organization.create", 100},
+	{"audit.app.copy-bits", 100},
+	{"audit.service_key.update", 100},
+	{"audit.service_instance.share", 100},
+	{"audit.service_instance.unshare", 100},
+	{"audit.service_binding.start_delete", 10},
+	{"audit.service_binding.start_create", 10},
+	{"audit.app.deployment.cancel", 10},
+	{"audit.app.task.cancel", 10},
+	{"audit.service_key.start_delete", 10},
+	{"audit.space.update", 10},
+	{"audit.app.ssh-unauthorized", 10},
+	{"audit.service_key.start_create", 10},
+	{"audit.app.process.terminate_instance", 1},
+	{"audit.app.droplet.download", 1},
+	{"audit.service_dashboard_client.create", 1},
+	{"audit.service_dashboard_client.delete", 1},
+	{"audit.service_route_binding.delete", 1},
+	{"audit.service_route_binding.create", 1},
+	{"blob.remove_orphan", 1},
+}
+
+// servicePlanBoilerplate is appended to the description and used as schemas of service plans, so that they are
+// about as large as the ones of real service brokers.
+const servicePlanBoilerplate = "Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet." +
+	"Duis autem vel eum iriure dolor in hendrerit in vulputate velit esse molestie consequat, vel illum dolore eu feugiat nulla facilisis at vero eros et accumsan et iusto odio dignissim qui blandit praesent luptatum zzril delenit augue duis dolore te feugait nulla facilisi. Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat." +
+	"Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea commodo consequat. Duis autem vel eum iriure dolor in hendrerit in vulputate velit esse molestie consequat, vel illum dolore eu feugiat nulla facilisis at vero eros et accumsan et iusto odio dignissim qui blandit praesent luptatum zzril delenit augue duis dolore te feugait nulla facilisi." +
+	"Nam liber tempor cum soluta nobis eleifend option congue nihil imperdiet doming id quod mazim placerat facer possim assum. Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat. Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea commodo consequat." +
+	"Duis autem vel eum iriure dolor in hendrerit in vulputate velit esse molestie consequat, vel illum dolore eu feugiat nulla facilisis." +
+	"At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, At accusam aliquyam diam diam dolore dolores duo eirmod eos erat, et nonumy sed tempor et et invidunt justo labore Stet clita ea et gubergren, kasd magna no rebum. sanctus sea sed takimata ut vero voluptua. est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat." +
+	"Consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus est Lorem ipsum dolor sit amet. Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat, sed diam voluptua. At vero eos et accusam et justo duo dolores et ea rebum. Stet clita kasd gubergren, no sea takimata sanctus."
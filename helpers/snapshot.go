@@ -0,0 +1,188 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// increase when the data created by the Seeder changes, to invalidate existing snapshots
+const snapshotVersion = 2
+
+const snapshotsTable = "perf_snapshots"
+
+// snapshotTable is a table written by the Seeder. Rows of tables with an id column are captured if their id is
+// larger than the largest id before seeding; rows of join tables if they belong to a captured row of the parent.
+type snapshotTable struct {
+	name         string
+	parent       string
+	column       string
+	parentColumn string
+	// columns identifying the rows of join tables in the cleanup manifest; the guid for all other tables
+	keyColumns []string
+}
+
+// tables in the order in which they are restored
+var snapshotTables = []snapshotTable{
+	{name: "quota_definitions"},
+	{name: "isolation_segments"},
+	{name: "organizations"},
+	{name: "organizations_isolation_segments", parent: "organizations", column: "organization_guid", parentColumn: "guid", keyColumns: []string{"organization_guid", "isolation_segment_guid"}},
+	{name: "spaces"},
+	{name: "space_labels"},
+	{name: "apps"},
+	{name: "buildpack_lifecycle_data"},
+	{name: "processes"},
+	{name: "packages"},
+	{name: "droplets"},
+	{name: "app_labels"},
+	{name: "security_groups"},
+	{name: "security_groups_spaces", parent: "security_groups", column: "security_group_id", parentColumn: "id", keyColumns: []string{"security_group_id", "space_id"}},
+	{name: "domains"},
+	{name: "service_brokers"},
+	{name: "services"},
+	{name: "service_plans"},
+	{name: "service_plan_visibilities"},
+	{name: "service_instances"},
+	{name: "service_instance_shares", parent: "service_instances", column: "service_instance_guid", parentColumn: "guid", keyColumns: []string{"service_instance_guid", "target_space_guid"}},
+	{name: "service_keys"},
+	{name: "events"},
+}
+
+// Snapshot copies the rows created by a Seeder into shadow tables in the CCDB, so that they can be restored
+// instead of seeding them again. Snapshots are identified by a name and only restored if their key, e.g. a hash
+// of the dataset and the schema version, is unchanged.
+type Snapshot struct {
+	seeder *Seeder
+	name   string
+	key    string
+	maxIds map[string]int
+}
+
+// NewSnapshot returns the snapshot with the given name. The key is combined with the CCDB schema version and the
+// test resource prefix, as the names of the snapshotted resources start with the prefix.
+func (s *Seeder) NewSnapshot(name string, key string) *Snapshot {
+	schemaVersion, err := readSchemaVersion(s.db, s.ctx)
+	checkError(err)
+	s.exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (name VARCHAR(255) NOT NULL PRIMARY KEY, snapshot_key VARCHAR(255) NOT NULL, snapshot_tables TEXT NOT NULL)", snapshotsTable))
+	return &Snapshot{
+		seeder: s,
+		name:   name,
+		key:    fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%d %s %s %s", snapshotVersion, key, schemaVersion, s.prefix)))),
+	}
+}
+
+// Restore copies the rows of the snapshot back and returns true, if a snapshot with the same key exists.
+func (snapshot *Snapshot) Restore() bool {
+	s := snapshot.seeder
+	var tables []string
+	rows, err := s.db.QueryContext(s.ctx, fmt.Sprintf("SELECT snapshot_tables FROM %s WHERE name = %s AND snapshot_key = %s", snapshotsTable, s.placeholder(1), s.placeholder(2)), snapshot.name, snapshot.key)
+	checkError(err)
+	for rows.Next() {
+		var snapshotTables string
+		checkError(rows.Scan(&snapshotTables))
+		tables = strings.Split(snapshotTables, ",")
+	}
+	checkError(rows.Err())
+	checkError(rows.Close())
+	if tables == nil {
+		log.Printf("No snapshot '%s' of the current dataset and schema version found", snapshot.name)
+		return false
+	}
+
+	defer s.logStep("restore snapshot '%s'", snapshot.name)()
+	for _, table := range tables {
+		if table == "selected_orgs" {
+			s.exec("DROP TABLE IF EXISTS selected_orgs")
+			s.exec("CREATE TABLE selected_orgs(id INT NOT NULL PRIMARY KEY)")
+		} else {
+			s.recordCopied(table, snapshotKeyColumns(table), snapshot.shadowTable(table))
+		}
+		s.exec(fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", table, snapshot.shadowTable(table)))
+		if table == "droplets" {
+			s.assignAppDroplets()
+		}
+	}
+	return true
+}
+
+func snapshotKeyColumns(name string) []string {
+	for _, table := range snapshotTables {
+		if table.name == name && table.keyColumns != nil {
+			return table.keyColumns
+		}
+	}
+	return []string{"guid"}
+}
+
+// Begin records the largest ids of the snapshot tables before seeding.
+func (snapshot *Snapshot) Begin() {
+	s := snapshot.seeder
+	snapshot.maxIds = map[string]int{}
+	for _, table := range snapshotTables {
+		if table.parent == "" {
+			snapshot.maxIds[table.name] = ExecuteSelectStatementOneRow(s.db, s.ctx, fmt.Sprintf("SELECT COALESCE(MAX(id), 0) FROM %s", table.name))
+		}
+	}
+}
+
+// Take replaces the snapshot with the rows created since Begin, and the selected_orgs table if it was created.
+func (snapshot *Snapshot) Take() {
+	s := snapshot.seeder
+	defer s.logStep("take snapshot '%s'", snapshot.name)()
+
+	snapshot.Drop()
+
+	var tables []string
+	for _, table := range snapshotTables {
+		var condition string
+		if table.parent == "" {
+			condition = fmt.Sprintf("id > %d", snapshot.maxIds[table.name])
+		} else {
+			condition = fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE id > %d)", table.column, table.parentColumn, table.parent, snapshot.maxIds[table.parent])
+		}
+		snapshot.copy(table.name, condition)
+		if table.name == "apps" {
+			// the droplets are restored after the apps, and assigned to them again afterwards
+			s.exec(fmt.Sprintf("UPDATE %s SET droplet_guid = NULL", snapshot.shadowTable(table.name)))
+		}
+		tables = append(tables, table.name)
+	}
+	if s.selectedOrgs {
+		snapshot.copy("selected_orgs", "1 = 1")
+		tables = append(tables, "selected_orgs")
+	}
+
+	_, err := s.db.ExecContext(s.ctx, fmt.Sprintf("INSERT INTO %s (name, snapshot_key, snapshot_tables) VALUES (%s, %s, %s)", snapshotsTable, s.placeholder(1), s.placeholder(2), s.placeholder(3)),
+		snapshot.name, snapshot.key, strings.Join(tables, ","))
+	checkError(err)
+}
+
+// Drop removes the snapshot.
+func (snapshot *Snapshot) Drop() {
+	s := snapshot.seeder
+	_, err := s.db.ExecContext(s.ctx, fmt.Sprintf("DELETE FROM %s WHERE name = %s", snapshotsTable, s.placeholder(1)), snapshot.name)
+	checkError(err)
+	for _, table := range append(snapshotTables, snapshotTable{name: "selected_orgs"}) {
+		s.exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", snapshot.shadowTable(table.name)))
+	}
+}
+
+func (snapshot *Snapshot) copy(table string, condition string) {
+	s := snapshot.seeder
+	shadowTable := snapshot.shadowTable(table)
+	if s.testConfig.DatabaseType == PsqlDb {
+		s.exec(fmt.Sprintf("CREATE TABLE %s (LIKE %s)", shadowTable, table))
+	} else {
+		s.exec(fmt.Sprintf("CREATE TABLE %s LIKE %s", shadowTable, table))
+	}
+	s.exec(fmt.Sprintf("INSERT INTO %s SELECT * FROM %s WHERE %s", shadowTable, table, condition))
+}
+
+// shadowTable returns the name of the table holding the snapshot of the given table; it is derived from a hash of
+// the snapshot name to stay within the identifier length limits of PostgreSQL and MySQL.
+func (snapshot *Snapshot) shadowTable(table string) string {
+	hash := sha256.Sum256([]byte(snapshot.name))
+	return fmt.Sprintf("perf_snapshot_%x_%s", hash[:4], table)
+}
@@ -0,0 +1,146 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v4"
+)
+
+// the largest per_page value accepted by the Cloud Controller
+const maxPageSize = 5000
+
+// ValidateConfig returns all problems of the configuration that can be found without connecting to the
+// foundation, e.g. missing required keys or values out of range.
+func ValidateConfig(testConfig Config) []string {
+	var problems []string
+	problem := func(key string, format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("'%s' %s (set it in the config file, as %s or as --%s)", key, fmt.Sprintf(format, args...), configEnvVar(key), key))
+	}
+
+	if testConfig.API == "" {
+		problem("api", "is required")
+	}
+	if testConfig.GetAdminClient() == "" && (testConfig.GetAdminUser() == "" || testConfig.GetAdminPassword() == "") {
+		problem("users.admin.username", "and 'users.admin.password', or 'users.admin.client' and 'users.admin.clientsecret', are required")
+	}
+	if testConfig.GetAdminClient() != "" && testConfig.GetAdminClientSecret() == "" {
+		problem("users.admin.clientsecret", "is required with 'users.admin.client'")
+	}
+	if testConfig.Samples < 1 {
+		problem("samples", "must be at least 1, is %d", testConfig.Samples)
+	}
+	if testConfig.LargePageSize < 1 || testConfig.LargePageSize > maxPageSize {
+		problem("large_page_size", "must be between 1 and %d, the maximum page size of the Cloud Controller, is %d", maxPageSize, testConfig.LargePageSize)
+	}
+	if testConfig.LargeElementsFilter < 1 {
+		problem("large_elements_filter", "must be at least 1, is %d", testConfig.LargeElementsFilter)
+	}
+	if testConfig.BasicTimeout <= 0 {
+		problem("basic_timeout", "must be positive, is %v", testConfig.BasicTimeout)
+	}
+	if testConfig.LongTimeout <= 0 {
+		problem("long_timeout", "must be positive, is %v", testConfig.LongTimeout)
+	}
+	if testConfig.Scale <= 0 {
+		problem("scale", "must be positive, is %v", testConfig.Scale)
+	}
+	if testConfig.TestResourcePrefix == "" {
+		problem("test_resource_prefix", "is required, as all resources named with it are deleted")
+	}
+
+	if testConfig.DatabaseType != PsqlDb && testConfig.DatabaseType != MysqlDb {
+		problem("database_type", "must be one of '%s' or '%s', is '%s'", PsqlDb, MysqlDb, testConfig.DatabaseType)
+	} else {
+		if testConfig.CcdbConnection == "" {
+			problem("ccdb_connection", "is required")
+		} else if err := parseConnectionString(testConfig.DatabaseType, testConfig.CcdbConnection); err != nil {
+			problem("ccdb_connection", "is not a valid %s connection string: %s", testConfig.DatabaseType, err.Error())
+		}
+		if testConfig.UaadbConnection != "" {
+			if err := parseConnectionString(testConfig.DatabaseType, testConfig.UaadbConnection); err != nil {
+				problem("uaadb_connection", "is not a valid %s connection string: %s", testConfig.DatabaseType, err.Error())
+			}
+		}
+	}
+
+	if testConfig.Load.Workers < 0 {
+		problem("load.workers", "must not be negative, is %d", testConfig.Load.Workers)
+	}
+	if testConfig.Load.Rate < 0 {
+		problem("load.rate", "must not be negative, is %v", testConfig.Load.Rate)
+	}
+	if testConfig.Load.Duration < 0 {
+		problem("load.duration", "must not be negative, is %v", testConfig.Load.Duration)
+	}
+	if testConfig.Load.OpenLoop && testConfig.Load.Rate == 0 {
+		problem("load.rate", "is required with 'load.open_loop'")
+	}
+	if _, err := readBudgets(testConfig); err != nil {
+		problem("budgets", "cannot be read: %s", err.Error())
+	}
+	return problems
+}
+
+// parseConnectionString checks that the connection string can be parsed by the driver; the returned error does not
+// contain the connection string, which usually contains the password.
+func parseConnectionString(databaseType string, connection string) error {
+	var err error
+	if databaseType == MysqlDb {
+		_, err = mysql.ParseDSN(connection)
+	} else {
+		_, err = pgx.ParseConfig(connection)
+	}
+	if err != nil {
+		return errors.New(strings.ReplaceAll(err.Error(), connection, "<connection string>"))
+	}
+	return nil
+}
+
+// CheckConnectivity returns the problems connecting to the CCDB, the UAADB and the API of the configuration. It
+// expects a configuration without problems found by ValidateConfig.
+func CheckConnectivity(testConfig Config) []string {
+	var problems []string
+	ctx, cancel := context.WithTimeout(context.Background(), testConfig.BasicTimeout)
+	defer cancel()
+
+	driverName := "pgx"
+	if testConfig.DatabaseType == MysqlDb {
+		driverName = "mysql"
+	}
+	databases := []struct{ key, connection string }{
+		{"ccdb_connection", testConfig.CcdbConnection},
+		{"uaadb_connection", testConfig.UaadbConnection},
+	}
+	for _, database := range databases {
+		if database.connection == "" {
+			continue
+		}
+		if err := pingDatabase(ctx, driverName, database.connection); err != nil {
+			problems = append(problems, fmt.Sprintf("cannot connect to the database of '%s': %s", database.key, err.Error()))
+		}
+	}
+
+	response, err := NewCCClient(testConfig).Do(ctx, http.MethodGet, "/v3/info", nil, nil)
+	switch {
+	case err != nil:
+		problems = append(problems, fmt.Sprintf("cannot reach the API at %s: %s (check 'api', 'use_http' and 'skip_ssl_validation')", testConfig.GetApiEndpoint(), err.Error()))
+	case response.StatusCode != http.StatusOK:
+		problems = append(problems, fmt.Sprintf("GET %s/v3/info failed with %s (check 'api')", testConfig.GetApiEndpoint(), response.Status))
+	}
+	return problems
+}
+
+func pingDatabase(ctx context.Context, driverName string, connection string) error {
+	db, err := sql.Open(driverName, connection)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.PingContext(ctx)
+}
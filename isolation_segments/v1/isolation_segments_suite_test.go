@@ -3,7 +3,6 @@ package isolation_segments
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,43 +23,18 @@ var ctx context.Context
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	orgs              = 20000
-	isolationSegments = 500
-
-	orgsWithinIsolationSegments = orgs / 2 // 50%
-)
-
 var _ = BeforeSuite(func() {
-	Expect(isolationSegments).To(BeNumerically(">=", testConfig.LargePageSize))
+	dataset = helpers.LoadDataset(testConfig)
+	Expect(dataset.Int("isolation_segments")).To(BeNumerically(">=", testConfig.LargePageSize))
 
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	// create orgs
-	createOrgsStatement := fmt.Sprintf("create_orgs(%v)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgsStatement, testConfig)
-
-	// copy ids of orgs relevant for regular user
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
-
-	// create isolation segments
-	createIsolationSegmentsStatement := fmt.Sprintf("create_isolation_segments(%v)", isolationSegments)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createIsolationSegmentsStatement, testConfig)
-
-	// assign orgs to isolation segments; n orgs are assigned to a random isolation segment
-	assignOrgsToIsolationSegmentsStatement := fmt.Sprintf("assign_orgs_to_isolation_segments(%d)", orgsWithinIsolationSegments)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignOrgsToIsolationSegmentsStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
-	// assign the regular user to all orgs
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	orgsAssignedToRegularUser := orgs
-	assignUserAsOrgManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_managers", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgManager, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
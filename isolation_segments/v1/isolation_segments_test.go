@@ -21,11 +21,11 @@ var _ = Describe("isolation segments", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET isolation_segments", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/isolation_segments")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/isolation_segments")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -34,11 +34,11 @@ var _ = Describe("isolation segments", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/isolation_segments", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/isolation_segments")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/isolation_segments")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -47,11 +47,11 @@ var _ = Describe("isolation segments", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/isolation_segments", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/isolation_segments?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/isolation_segments?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -66,11 +66,11 @@ var _ = Describe("isolation segments", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/isolation_segments/:guid/relationships/organizations", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s/relationships/organizations", isolationSegmentGUID))
+						helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s/relationships/organizations", isolationSegmentGUID))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -83,11 +83,11 @@ var _ = Describe("isolation segments", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/isolation_segments/:guid/relationships/organizations", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s/relationships/organizations", isolationSegmentGUID))
+						helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s/relationships/organizations", isolationSegmentGUID))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -106,11 +106,11 @@ var _ = Describe("isolation segments", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/isolation_segments/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -119,12 +119,12 @@ var _ = Describe("isolation segments", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("PATCH /v3/isolation_segments/:guid", func() {
 							data := `{ "metadata": { "annotations": { "test": "PATCH /v3/isolation_segments/:guid" } } }`
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -139,11 +139,11 @@ var _ = Describe("isolation segments", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/isolation_segments/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/isolation_segments/%s", isolationSegmentGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
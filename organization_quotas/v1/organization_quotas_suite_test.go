@@ -3,7 +3,6 @@ package organization_quotas
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,31 +23,17 @@ var ctx context.Context
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	orgs      = 100000
-	orgQuotas = 50 // results in 100000 / 50 = 2000 orgs per quota
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	createOrgStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgStatement, testConfig)
-
-	createQuotasStatement := fmt.Sprintf("create_org_quotas_and_distribute_orgs(%d)", orgQuotas)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createQuotasStatement, testConfig)
-
-	orgsAssignedToRegularUser := orgs / 10
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	assignDisjointOrgRoles := fmt.Sprintf("assign_user_disjoint_org_roles('%s')", regularUserGUID)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignDisjointOrgRoles, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
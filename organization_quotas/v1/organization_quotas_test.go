@@ -17,11 +17,11 @@ var _ = Describe("organization_quotas", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/organization_quotas", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/organization_quotas")
+						helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/organization_quotas")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -30,11 +30,11 @@ var _ = Describe("organization_quotas", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/organization_quotas", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/organization_quotas")
+						helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/organization_quotas")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -43,11 +43,11 @@ var _ = Describe("organization_quotas", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/organization_quotas", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/organization_quotas?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/organization_quotas?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -3,7 +3,6 @@ package organizations
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,27 +23,17 @@ var ctx context.Context
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	orgs = 100000
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	createOrgStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgStatement, testConfig)
-
-	orgsAssignedToRegularUser := orgs / 10
-	selectOrgsStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	assignDisjointOrgRoles := fmt.Sprintf("assign_user_disjoint_org_roles('%s')", regularUserGUID)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignDisjointOrgRoles, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
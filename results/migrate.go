@@ -0,0 +1,70 @@
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// database types recorded as ccdbVersion by schema version 1
+var databaseTypes = map[string]bool{"postgres": true, "mysql": true}
+
+// Migrate upgrades the file to the current schema version, one version at a time. It does nothing for files of the
+// current version.
+func Migrate(file *File) {
+	if file.SchemaVersion == 0 {
+		file.SchemaVersion = 1
+	}
+	if file.SchemaVersion == 1 {
+		migrateToVersion2(file)
+	}
+}
+
+func migrateToVersion2(file *File) {
+	if databaseTypes[file.CCDBVersion] {
+		file.DatabaseType = file.CCDBVersion
+		file.CCDBVersion = ""
+	}
+	for _, measurements := range file.Measurements {
+		for name, measurement := range measurements {
+			if measurement.Median == 0 && measurement.P50 == 0 && len(measurement.Results) > 0 {
+				measurement.Median = Percentile(measurement.Results, 50)
+				measurement.P50 = measurement.Median
+				measurement.P90 = Percentile(measurement.Results, 90)
+				measurement.P95 = Percentile(measurement.Results, 95)
+				measurement.P99 = Percentile(measurement.Results, 99)
+				measurements[name] = measurement
+			}
+		}
+	}
+	file.SchemaVersion = 2
+}
+
+// MigrateFile rewrites the result file in the current schema version. It returns false if the file already had the
+// current version.
+func MigrateFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var version struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	err = json.Unmarshal(data, &version)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	if version.SchemaVersion == CurrentSchemaVersion {
+		return false, nil
+	}
+
+	file, err := parse(path, data)
+	if err != nil {
+		return false, err
+	}
+	data, err = json.Marshal(file)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, data, 0644)
+}
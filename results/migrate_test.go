@@ -0,0 +1,169 @@
+package results
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testdata/v1.json is a result file of schema version 1, as written by the JsonReporter before schemaVersion was
+// added: it records the database type as ccdbVersion, and its measurements lack the median and the percentiles.
+const v1Fixture = "testdata/v1.json"
+
+func TestReadMigratesVersion1(t *testing.T) {
+	file, err := Read(v1Fixture)
+	if err != nil {
+		t.Fatalf("Read returned %v", err)
+	}
+	checkMigratedVersion1(t, file)
+}
+
+func TestMigrateVersion1(t *testing.T) {
+	data, err := os.ReadFile(v1Fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &File{}
+	if err := json.Unmarshal(data, file); err != nil {
+		t.Fatal(err)
+	}
+	if file.SchemaVersion != 0 {
+		t.Fatalf("fixture has schema version %d", file.SchemaVersion)
+	}
+	Migrate(file)
+	checkMigratedVersion1(t, file)
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate returned %v", err)
+	}
+
+	// migrating again changes nothing
+	migrated := *file
+	Migrate(file)
+	if !reflect.DeepEqual(*file, migrated) {
+		t.Errorf("Migrate changed a file of the current version")
+	}
+}
+
+func TestMigrateFileVersion1(t *testing.T) {
+	path := copyFixture(t, t.TempDir(), "result.json")
+	migrated, err := MigrateFile(path)
+	if err != nil || !migrated {
+		t.Fatalf("MigrateFile returned %t, %v", migrated, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &File{}
+	if err := json.Unmarshal(data, file); err != nil {
+		t.Fatal(err)
+	}
+	checkMigratedVersion1(t, file)
+
+	migrated, err = MigrateFile(path)
+	if err != nil || migrated {
+		t.Errorf("MigrateFile of a migrated file returned %t, %v", migrated, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     File
+		expected string
+	}{
+		{"valid", File{SchemaVersion: CurrentSchemaVersion, Timestamp: 1}, ""},
+		{"newer schema version", File{SchemaVersion: CurrentSchemaVersion + 1, Timestamp: 1}, "schema version 3 is newer than the supported version 2"},
+		{"missing timestamp", File{SchemaVersion: CurrentSchemaVersion}, "timestamp is missing"},
+		{
+			"invalid measurements",
+			File{SchemaVersion: CurrentSchemaVersion, Timestamp: 1, Measurements: map[string]map[string]Measurement{"no headline": {RequestTimeMeasurement: {}}}},
+			"measurement 'request time' of 'no headline' has no results; measurement key 'no headline' is not of the form '<test headline>::<experiment>'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.file.Validate()
+			if (err == nil) != (test.expected == "") || (err != nil && err.Error() != test.expected) {
+				t.Errorf("Validate() = %v, expected '%s'", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestWalkSkipsInvalidFiles(t *testing.T) {
+	folder := t.TempDir()
+	suiteFolder := filepath.Join(folder, "apps-test-results", "v1")
+	if err := os.MkdirAll(suiteFolder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	copyFixture(t, suiteFolder, "apps-test-results-1.json")
+	writeFile(t, filepath.Join(suiteFolder, "apps-test-results-2.json"), "{")
+	writeFile(t, filepath.Join(suiteFolder, "apps-test-results-3.json"), `{"schemaVersion": 2}`)
+	copyFixture(t, suiteFolder, "apps-test-results-4.json")
+	writeFile(t, filepath.Join(suiteFolder, "apps-test-results-4-query-plans.json"), "[]")
+
+	var paths []string
+	err := Walk(folder, func(path string, file *File) error {
+		paths = append(paths, filepath.Base(path))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk returned %v", err)
+	}
+	expected := []string{"apps-test-results-1.json", "apps-test-results-4.json"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Walk visited %v, expected %v", paths, expected)
+	}
+}
+
+func checkMigratedVersion1(t *testing.T, file *File) {
+	t.Helper()
+	if file.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("schema version is %d, expected %d", file.SchemaVersion, CurrentSchemaVersion)
+	}
+	if file.DatabaseType != "postgres" || file.CCDBVersion != "" {
+		t.Errorf("database type is '%s' and CCDB version '%s', expected 'postgres' and ''", file.DatabaseType, file.CCDBVersion)
+	}
+	measurement, found := file.Measurements["apps::GET /v3/apps::as admin"][RequestTimeMeasurement]
+	if !found {
+		t.Fatalf("request time is missing")
+	}
+	statistics := []struct {
+		name     string
+		actual   float64
+		expected float64
+	}{
+		{"median", measurement.Median, 0.298},
+		{"p50", measurement.P50, 0.298},
+		{"p90", measurement.P90, 0.4666},
+		{"p95", measurement.P95, 0.4848},
+		{"p99", measurement.P99, 0.49936},
+	}
+	for _, s := range statistics {
+		if !almostEqual(s.actual, s.expected) {
+			t.Errorf("%s is %v, expected %v", s.name, s.actual, s.expected)
+		}
+	}
+}
+
+func copyFixture(t *testing.T, folder string, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(v1Fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(folder, name)
+	writeFile(t, path, string(data))
+	return path
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+package results
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Experiment is the set of measurements of one experiment in a result file.
+type Experiment struct {
+	// the key of the measurements, "<test headline>::<experiment>"
+	Key          string
+	Headline     string
+	Name         string
+	Measurements map[string]Measurement
+}
+
+// RequestTime returns the "request time" series of the experiment.
+func (experiment Experiment) RequestTime() (Measurement, bool) {
+	measurement, found := experiment.Measurements[RequestTimeMeasurement]
+	return measurement, found
+}
+
+// Read reads the result file, upgrades it to the current schema version and validates it.
+func Read(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parse(path, data)
+}
+
+func parse(path string, data []byte) (*File, error) {
+	file := &File{}
+	err := json.Unmarshal(data, file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	Migrate(file)
+	err = file.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid result file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Walk reads all result files below the folder, e.g. the results folder of the tests, in lexical order and calls fn
+// for each of them. Files written next to the result files, like query plans, are skipped, and so are result files
+// that cannot be read or are invalid, which are logged.
+func Walk(folder string, fn func(path string, file *File) error) error {
+	return filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !IsResultFile(path) {
+			return nil
+		}
+		file, err := Read(path)
+		if err != nil {
+			log.Printf("Skipping result file: %s", err.Error())
+			return nil
+		}
+		return fn(path, file)
+	})
+}
+
+// IsResultFile returns whether the path names a result file, as opposed to the other files written next to them.
+func IsResultFile(path string) bool {
+	return strings.HasSuffix(path, ".json") && !strings.HasSuffix(path, "-query-plans.json") && !strings.HasSuffix(path, "-ginkgo.json")
+}
+
+// Validate returns an error describing all problems of the file.
+func (file *File) Validate() error {
+	var problems []string
+	if file.SchemaVersion > CurrentSchemaVersion {
+		problems = append(problems, fmt.Sprintf("schema version %d is newer than the supported version %d", file.SchemaVersion, CurrentSchemaVersion))
+	}
+	if file.Timestamp <= 0 {
+		problems = append(problems, "timestamp is missing")
+	}
+	for key, measurements := range file.Measurements {
+		if !strings.Contains(key, "::") {
+			problems = append(problems, fmt.Sprintf("measurement key '%s' is not of the form '<test headline>::<experiment>'", key))
+		}
+		for name, measurement := range measurements {
+			if len(measurement.Results) == 0 {
+				problems = append(problems, fmt.Sprintf("measurement '%s' of '%s' has no results", name, key))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// Experiments returns the experiments of the file, ordered by their keys.
+func (file *File) Experiments() []Experiment {
+	var experiments []Experiment
+	for key, measurements := range file.Measurements {
+		headline, name, _ := strings.Cut(key, "::")
+		experiments = append(experiments, Experiment{Key: key, Headline: headline, Name: name, Measurements: measurements})
+	}
+	sort.Slice(experiments, func(i, j int) bool { return experiments[i].Key < experiments[j].Key })
+	return experiments
+}
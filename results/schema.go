@@ -0,0 +1,89 @@
+// Package results reads the result files written by helpers.GenerateReports, i.e.
+// <results folder>/<suite>-test-results/<test version>/<suite>-test-results-<timestamp>.json.
+//
+// Every result file records the version of its schema as schemaVersion; Read upgrades files of older schema
+// versions to the current one with Migrate. The schema versions are:
+//
+//   - 1: files without schemaVersion. ccdbVersion contains the database type, and measurements may lack the
+//     median and the percentiles.
+//   - 2: adds schemaVersion. ccdbVersion contains the CCDB schema version (the latest migration) and databaseType
+//     the database type; dataset, scale, parameters and the run metadata under run are recorded. If latency
+//     budgets are configured, their outcome is recorded as budgets.
+//
+// The measurements of a file are keyed by "<test headline>::<experiment>". Each experiment contains the series
+// "request time", and depending on the configuration the series of the request phases, the load mode and the query
+// statistics, keyed by their names.
+package results
+
+// CurrentSchemaVersion is the schema version of the result files written by helpers.GenerateReports.
+const CurrentSchemaVersion = 2
+
+// RequestTimeMeasurement is the name of the series of the measured requests, which every experiment contains.
+const RequestTimeMeasurement = "request time"
+
+// File is the content of a result file.
+type File struct {
+	SchemaVersion       int                               `json:"schemaVersion"`
+	Measurements        map[string]map[string]Measurement `json:"measurements"`
+	CfDeploymentVersion string                            `json:"cfDeploymentVersion"`
+	Timestamp           int64                             `json:"timestamp"`
+	CapiVersion         string                            `json:"capiVersion"`
+	CCDBVersion         string                            `json:"ccdbVersion"`
+	DatabaseType        string                            `json:"databaseType,omitempty"`
+	Dataset             string                            `json:"dataset,omitempty"`
+	Scale               float64                           `json:"scale,omitempty"`
+	Parameters          map[string]int                    `json:"parameters,omitempty"`
+	Run                 RunMetadata                       `json:"run"`
+	Budgets             []BudgetOutcome                   `json:"budgets,omitempty"`
+}
+
+// Measurement is a series of an experiment with its statistics. Durations are given in seconds.
+type Measurement struct {
+	Name          string      `json:"Name"`
+	Info          interface{} `json:"Info"`
+	Order         int         `json:"Order"`
+	Results       []float64   `json:"Results"`
+	Smallest      float64     `json:"Smallest"`
+	Largest       float64     `json:"Largest"`
+	Average       float64     `json:"Average"`
+	StdDeviation  float64     `json:"StdDeviation"`
+	Median        float64     `json:"Median"`
+	P50           float64     `json:"P50"`
+	P90           float64     `json:"P90"`
+	P95           float64     `json:"P95"`
+	P99           float64     `json:"P99"`
+	SmallestLabel string      `json:"SmallestLabel"`
+	LargestLabel  string      `json:"LargestLabel"`
+	AverageLabel  string      `json:"AverageLabel"`
+	Units         string      `json:"Units"`
+}
+
+// RunMetadata describes the environment of a test run, so that results of different runs can be compared.
+type RunMetadata struct {
+	// the commit of this repository the tests were run from
+	GitCommit   string `json:"gitCommit,omitempty"`
+	TestVersion string `json:"testVersion"`
+	Samples     int    `json:"samples"`
+	// versions of the Cloud Controller API as reported by the API root
+	CCAPIV2Version string `json:"ccApiV2Version,omitempty"`
+	CCAPIV3Version string `json:"ccApiV3Version,omitempty"`
+	// name, build and version of the foundation as reported by /v3/info
+	InfoName        string `json:"infoName,omitempty"`
+	InfoBuild       string `json:"infoBuild,omitempty"`
+	InfoVersion     int    `json:"infoVersion,omitempty"`
+	DatabaseVersion string `json:"databaseVersion,omitempty"`
+	CFCLIVersion    string `json:"cfCliVersion,omitempty"`
+	Host            string `json:"host,omitempty"`
+}
+
+// BudgetOutcome is the check of a statistic of the request time of an experiment against a latency budget.
+// Threshold and Value are given in seconds.
+type BudgetOutcome struct {
+	Experiment string `json:"experiment"`
+	// the experiment pattern of the budget
+	Budget    string  `json:"budget"`
+	Statistic string  `json:"statistic"`
+	Threshold float64 `json:"threshold"`
+	Value     float64 `json:"value"`
+	Passed    bool    `json:"passed"`
+}
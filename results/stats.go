@@ -0,0 +1,120 @@
+package results
+
+import (
+	"math"
+	"sort"
+)
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of the given values, interpolating linearly between the
+// closest ranks. It returns 0 for an empty slice.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// MannWhitneyU performs a two-sided Mann-Whitney U test on two independent samples and returns the U statistic of
+// the first sample together with the p-value. Small samples without ties use the exact distribution of U, all
+// others the normal approximation with tie and continuity correction.
+func MannWhitneyU(a, b []float64) (u float64, pValue float64) {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	type rankedValue struct {
+		value      float64
+		firstGroup bool
+	}
+	values := make([]rankedValue, 0, n1+n2)
+	for _, v := range a {
+		values = append(values, rankedValue{v, true})
+	}
+	for _, v := range b {
+		values = append(values, rankedValue{v, false})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].value < values[j].value })
+
+	// assign average ranks to ties and collect the tie correction term
+	rankSumA := 0.0
+	tieCorrection := 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].value == values[i].value {
+			j++
+		}
+		averageRank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].firstGroup {
+				rankSumA += averageRank
+			}
+		}
+		ties := float64(j - i)
+		tieCorrection += ties*ties*ties - ties
+		i = j
+	}
+
+	u = rankSumA - float64(n1*(n1+1))/2
+	mean := float64(n1*n2) / 2
+
+	if tieCorrection == 0 && n1 <= 20 && n2 <= 20 {
+		return u, exactMannWhitneyPValue(n1, n2, u)
+	}
+
+	n := float64(n1 + n2)
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance == 0 {
+		return u, 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return u, math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactMannWhitneyPValue computes the two-sided p-value of U from the number of rank arrangements of two samples
+// of the given sizes that produce each U value.
+func exactMannWhitneyPValue(n1, n2 int, u float64) float64 {
+	maxU := n1 * n2
+	// counts[i][j][k]: arrangements of i and j elements with U = k
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, maxU+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for k := 0; k <= i*j; k++ {
+				if k >= j {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				counts[i][j][k] += counts[i][j-1][k]
+			}
+		}
+	}
+
+	total := 0.0
+	for _, c := range counts[n1][n2] {
+		total += c
+	}
+	lowerU := math.Min(u, float64(maxU)-u)
+	tail := 0.0
+	for k := 0; float64(k) <= lowerU; k++ {
+		tail += counts[n1][n2][k]
+	}
+	return math.Min(1, 2*tail/total)
+}
@@ -0,0 +1,90 @@
+package results
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		p        float64
+		expected float64
+	}{
+		{"empty", nil, 50, 0},
+		{"single value", []float64{3}, 95, 3},
+		{"minimum", []float64{4, 1, 3, 2}, 0, 1},
+		{"maximum", []float64{4, 1, 3, 2}, 100, 4},
+		{"median of odd count", []float64{5, 1, 3}, 50, 3},
+		{"median of even count interpolates", []float64{4, 1, 3, 2}, 50, 2.5},
+		{"interpolates between closest ranks", []float64{10, 20, 30, 40, 50}, 90, 46},
+		{"ties", []float64{2, 2, 2, 2}, 95, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := Percentile(test.values, test.p); !almostEqual(actual, test.expected) {
+				t.Errorf("Percentile(%v, %v) = %v, expected %v", test.values, test.p, actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestPercentileDoesNotSortValues(t *testing.T) {
+	values := []float64{3, 1, 2}
+	Percentile(values, 50)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("Percentile changed its input to %v", values)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []float64
+		expectedU float64
+		expectedP float64
+	}{
+		{"empty sample", nil, []float64{1, 2}, 0, 1},
+		{"single values", []float64{1}, []float64{2}, 0, 1},
+		// exact: 1 of the C(6,3) = 20 arrangements has U = 0, two-sided
+		{"exact, separated samples", []float64{1, 2, 3}, []float64{4, 5, 6}, 0, 0.1},
+		{"exact, U of the larger sample", []float64{4, 5, 6}, []float64{1, 2, 3}, 9, 0.1},
+		{"exact, separated samples of 5", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0, 2.0 / 252},
+		// exact: 24 of the C(8,4) = 70 arrangements have U <= 6
+		{"exact, interleaved samples", []float64{1, 3, 5, 7}, []float64{2, 4, 6, 8}, 6, 48.0 / 70},
+		// normal approximation with tie and continuity correction
+		{"ties", []float64{1, 1, 2, 2, 3}, []float64{2, 3, 3, 4, 4}, 3, 0.05241162867102868},
+		{"all values equal", []float64{1, 1}, []float64{1, 1}, 2, 1},
+		// normal approximation for samples larger than 20
+		{"large samples", evenNumbers(21), oddNumbers(21), 210, 0.8013831883084928},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, p := MannWhitneyU(test.a, test.b)
+			if !almostEqual(u, test.expectedU) || !almostEqual(p, test.expectedP) {
+				t.Errorf("MannWhitneyU(%v, %v) = %v, %v, expected %v, %v", test.a, test.b, u, p, test.expectedU, test.expectedP)
+			}
+		})
+	}
+}
+
+func evenNumbers(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(2 * i)
+	}
+	return values
+}
+
+func oddNumbers(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(2*i + 1)
+	}
+	return values
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -3,7 +3,6 @@ package roles
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,52 +23,17 @@ var ctx context.Context
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	orgs = 100000
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-	
-	// create orgs
-	createOrgStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
-	// copy ids of orgs relevant for regular user
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
-
-	// create spaces
-	spacesPerOrg := 1
-	createSpacesStatement := fmt.Sprintf("create_spaces(%d)", spacesPerOrg)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createSpacesStatement, testConfig)
-
-	// assign the regular user multiple org roles in each 10% of the orgs
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	orgsAssignedToRegularUser := orgs / 10
-	assignUserAsOrgManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_managers", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgManager, testConfig)
-	assignUserAsOrgBillingManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_billing_managers", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgBillingManager, testConfig)
-	assignUserAsOrgAuditor := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_auditors", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgAuditor, testConfig)
-	assignUserAsOrgUser := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_users", orgsAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgUser, testConfig)
-
-	// assign the regular user multiple space roles in each 10% of the spaces
-	spacesAssignedToRegularUser := orgs * spacesPerOrg / 10
-	assignUserAsSpaceManager := fmt.Sprintf("assign_user_as_space_role('%s', '%s', %d)", regularUserGUID, "spaces_managers", spacesAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsSpaceManager, testConfig)
-	assignUserAsSpaceDeveloper := fmt.Sprintf("assign_user_as_space_role('%s', '%s', %d)", regularUserGUID, "spaces_developers", spacesAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsSpaceDeveloper, testConfig)
-	assignUserAsSpaceSupporter := fmt.Sprintf("assign_user_as_space_role('%s', '%s', %d)", regularUserGUID, "spaces_supporters", spacesAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsSpaceSupporter, testConfig)
-	assignUserAsSpaceAuditor := fmt.Sprintf("assign_user_as_space_role('%s', '%s', %d)", regularUserGUID, "spaces_auditors", spacesAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsSpaceAuditor, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
@@ -19,11 +19,11 @@ var _ = Describe("roles", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/roles", func() {
-							helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/roles")
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/roles")
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -32,11 +32,11 @@ var _ = Describe("roles", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/roles", func() {
-							helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/roles?per_page=%d", testConfig.LargePageSize))
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/roles?per_page=%d", testConfig.LargePageSize))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -64,11 +64,11 @@ var _ = Describe("roles", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/roles", func() {
-							helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/roles")
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/roles")
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -77,11 +77,11 @@ var _ = Describe("roles", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/roles?types=org_manager,space_developer", func() {
-							helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/roles?types=org_manager,space_developer")
+							helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/roles?types=org_manager,space_developer")
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -90,13 +90,13 @@ var _ = Describe("roles", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/roles?organization_guids=:guids&space_guids=:guids", func() {
-							helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf(
 								"/v3/roles?organization_guids=%v&space_guids=%v",
 								strings.Join(orgGuidsList[:], ","), strings.Join(spaceGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
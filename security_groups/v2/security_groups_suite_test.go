@@ -3,7 +3,6 @@ package security_groups
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -16,6 +15,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -23,52 +23,19 @@ var ctx context.Context
 
 const test_version = "v2"
 
-const (
-	// main test parameters:
-	spaces         = 500
-	securityGroups = 500
-
-	spacesWithSecurityGroups = spaces / 2         // 50%
-	securityGroupsPerSpace   = securityGroups / 2 // 50%
-)
-
 var _ = BeforeSuite(func() {
-	Expect(spaces).To(BeNumerically(">=", testConfig.LargeElementsFilter))
-	Expect(securityGroups).To(BeNumerically(">=", testConfig.LargePageSize))
+	dataset = helpers.LoadDataset(testConfig)
+	Expect(dataset.Int("spaces")).To(BeNumerically(">=", testConfig.LargeElementsFilter))
+	Expect(dataset.Int("security_groups")).To(BeNumerically(">=", testConfig.LargePageSize))
 
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
-
-	// create orgs and spaces; as the number of orgs is not relevant for these tests, all spaces are created in a single org
-	orgs := 1
-	spacesPerOrg := spaces / orgs
-	createOrgsStatement := fmt.Sprintf("create_orgs(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createOrgsStatement, testConfig)
-
-	selectOrgsRandomlyStatement := fmt.Sprintf("create_selected_orgs_table(%d)", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, selectOrgsRandomlyStatement, testConfig)
-
-	createSpacesStatement := fmt.Sprintf("create_spaces(%d)", spacesPerOrg)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createSpacesStatement, testConfig)
-
-	// create security groups
-	createSecurityGroupsStatement := fmt.Sprintf("create_security_groups(%d)", securityGroups)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createSecurityGroupsStatement, testConfig)
-
-	// assign security groups to spaces; n spaces have each m security groups (randomly) assigned (a security group can be assigned to multiple spaces)
-	assignSecurityGroupsToSpacesStatement := fmt.Sprintf("assign_security_groups_to_spaces(%d, %d)", spacesWithSecurityGroups, securityGroupsPerSpace)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignSecurityGroupsToSpacesStatement, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
 	regularUserGUID := helpers.GetUserGUID(testSetup.RegularUserContext(), testConfig)
-	assignUserAsOrgManager := fmt.Sprintf("assign_user_as_org_role('%s', '%s', %d)", regularUserGUID, "organizations_managers", orgs)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsOrgManager, testConfig)
-
-	// assign the regular user to all spaces
-	spacesAssignedToRegularUser := spaces
-	assignUserAsSpaceDeveloper := fmt.Sprintf("assign_user_as_space_role('%s', '%s', %d)", regularUserGUID, "spaces_developers", spacesAssignedToRegularUser)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, assignUserAsSpaceDeveloper, testConfig)
+	dataset.Seed(seeder, map[string]string{"regular": regularUserGUID})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
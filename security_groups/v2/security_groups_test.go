@@ -20,11 +20,11 @@ var _ = Describe("security groups", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/security_groups", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/security_groups")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/security_groups")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -33,11 +33,11 @@ var _ = Describe("security groups", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/security_groups", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/security_groups")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/security_groups")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -46,11 +46,11 @@ var _ = Describe("security groups", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/security_groups", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/security_groups?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/security_groups?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -59,13 +59,13 @@ var _ = Describe("security groups", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					spaceGUIDs := getRandomSpacesWithSecurityGroups()
 
 					experiment.MeasureDuration("GET /v3/security_groups", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, fmt.Sprintf("/v3/security_groups?running_space_guids=%s", strings.Join(spaceGUIDs, ",")))
+						helpers.TimeCCRequest(testConfig.LongTimeout, fmt.Sprintf("/v3/security_groups?running_space_guids=%s", strings.Join(spaceGUIDs, ",")))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -77,13 +77,13 @@ var _ = Describe("security groups", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						securityGroupGUID := getRandomSecurityGroup()
 
 						experiment.MeasureDuration("GET /v3/security_groups/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -92,14 +92,14 @@ var _ = Describe("security groups", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						securityGroupGUID := getRandomSecurityGroup()
 
 						experiment.MeasureDuration("PATCH /v3/security_groups/:guid", func() {
 							data := fmt.Sprintf(`{"name":"%s-updated-security-group-%s"}`, testConfig.GetNamePrefix(), securityGroupGUID)
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "-X", "PATCH", "-d", data, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -108,15 +108,15 @@ var _ = Describe("security groups", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						securityGroupGUID := getRandomSecurityGroup()
 
 						experiment.MeasureDuration("DELETE /v3/security_groups/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "-X", "DELETE", fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "-X", "DELETE", fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
 						})
 
 						helpers.WaitToFail(testSetup.AdminUserContext(), testConfig, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -127,13 +127,13 @@ var _ = Describe("security groups", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						securityGroupGUID := getRandomSecurityGroup()
 
 						experiment.MeasureDuration("GET /v3/security_groups/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/security_groups/%s", securityGroupGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -21,11 +21,11 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/service_instances", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/service_instances")
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/service_instances")
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -34,11 +34,11 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/service_instances", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_instances?per_page=%d", testConfig.LargePageSize))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_instances?per_page=%d", testConfig.LargePageSize))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -48,7 +48,7 @@ var _ = Describe("service instances", func() {
 
 				var pages int
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					exitCode, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, "/v3/service_instances")
+					exitCode, body := helpers.CCRequestReturning(testConfig.LongTimeout, "/v3/service_instances")
 					Expect(exitCode).To(Equal(0))
 					Expect(body).To(ContainSubstring("200 OK"))
 					response := helpers.ParseResponseBody(helpers.RemoveDebugOutput(body))
@@ -56,11 +56,11 @@ var _ = Describe("service instances", func() {
 				})
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/service_instances", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_instances?page=%d", pages))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_instances?page=%d", pages))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -71,11 +71,11 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/service_instances", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/service_instances")
+							helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/service_instances")
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -88,14 +88,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						orgGuidList := getRandomOrgGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?organization_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?organization_guids=%v", orgGuidList[0]))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -104,14 +104,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						orgGuidList := getRandomOrgGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?organization_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?per_page=%d&organization_guids=%v", testConfig.LargePageSize, strings.Join(orgGuidList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -124,14 +124,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						spaceGuidList := getRandomSpaceGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?space_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?per_page=%d&space_guids=%v", testConfig.LargePageSize, spaceGuidList[0]))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -140,14 +140,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						spaceGuidList := getRandomSpaceGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?space_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?&space_guids=%v", strings.Join(spaceGuidList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -160,14 +160,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanGuidsList := getRandomServicePlanGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?service_plan_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?service_plan_guids=%v", servicePlanGuidsList[0]))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -176,14 +176,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanGuidsList := getRandomServicePlanGuids()
 
 						experiment.MeasureDuration("GET /v3/service_instances?service_plan_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?per_page=%d&service_plan_guids=%v", testConfig.LargePageSize, strings.Join(servicePlanGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -196,14 +196,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanNamesList := getRandomServicePlanNames()
 
 						experiment.MeasureDuration("GET /v3/service_instances?service_plan_names=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?service_plan_names=%v", strings.Join(servicePlanNamesList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -212,14 +212,14 @@ var _ = Describe("service instances", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanNamesList := getRandomServicePlanNames()
 
 						experiment.MeasureDuration("GET /v3/service_instances?service_plan_names=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_instances?per_page=%d&service_plan_names=%v", testConfig.LargePageSize, strings.Join(servicePlanNamesList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -17,6 +17,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var prefix string
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
@@ -28,20 +29,18 @@ var spaceWithExhaustedServiceKeysGUID string
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	serviceInstancesPerSpace      = 5000 // i.e. 10000 in 2 spaces
-	serviceKeysPerServiceInstance = 20   // i.e. 100000 per space/org
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+	serviceInstancesPerSpace := dataset.Int("service_instances_per_space")
+	serviceKeysPerServiceInstance := dataset.Int("service_keys_per_service_instance")
 	Expect(serviceInstancesPerSpace).To(BeNumerically(">=", testConfig.LargeElementsFilter))
 
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	prefix = testConfig.GetNamePrefix()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
 	// create service and service plan
 	serviceId := createService()
@@ -59,18 +58,14 @@ var _ = BeforeSuite(func() {
 	spaceWithExhaustedServiceKeysId, spaceWithExhaustedServiceKeysGUID = createSpace(orgWithExhaustedServiceKeysId)
 
 	// create service instances
-	createServiceInstancesStatement := fmt.Sprintf("create_service_instances(%d, %d, %d)", spaceWithUnlimitedServiceKeysId, servicePlanId, serviceInstancesPerSpace)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createServiceInstancesStatement, testConfig)
+	seeder.CreateServiceInstances(spaceWithUnlimitedServiceKeysId, servicePlanId, serviceInstancesPerSpace)
 
-	createServiceInstancesStatement = fmt.Sprintf("create_service_instances(%d, %d, %d)", spaceWithExhaustedServiceKeysId, servicePlanId, serviceInstancesPerSpace)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createServiceInstancesStatement, testConfig)
+	seeder.CreateServiceInstances(spaceWithExhaustedServiceKeysId, servicePlanId, serviceInstancesPerSpace)
 
 	// create service keys
-	createServiceKeysStatement := fmt.Sprintf("create_service_keys_for_service_instances(%d, %d)", spaceWithUnlimitedServiceKeysId, serviceKeysPerServiceInstance)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createServiceKeysStatement, testConfig)
+	seeder.CreateServiceKeysForServiceInstances(spaceWithUnlimitedServiceKeysId, serviceKeysPerServiceInstance)
 
-	createServiceKeysStatement = fmt.Sprintf("create_service_keys_for_service_instances(%d, %d)", spaceWithExhaustedServiceKeysId, serviceKeysPerServiceInstance)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createServiceKeysStatement, testConfig)
+	seeder.CreateServiceKeysForServiceInstances(spaceWithExhaustedServiceKeysId, serviceKeysPerServiceInstance)
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
@@ -96,7 +91,7 @@ func createServicePlan(serviceId int) int {
 func createQuotaDefinition(totalServiceKeys int) int {
 	quotaDefinitionGuid := uuid.NewString()
 	quotaDefinitionName := fmt.Sprintf("%s-quota-definition-%s", prefix, quotaDefinitionGuid)
-	totalServices := serviceInstancesPerSpace
+	totalServices := dataset.Int("service_instances_per_space")
 	createQuotaDefinitionStatement := fmt.Sprintf(
 		"INSERT INTO quota_definitions (guid, name, non_basic_services_allowed, total_services, memory_limit, total_routes, total_service_keys) VALUES ('%s', '%s', false, %d, 0, 0, %d)",
 		quotaDefinitionGuid, quotaDefinitionName, totalServices, totalServiceKeys)
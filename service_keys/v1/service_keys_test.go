@@ -29,16 +29,16 @@ var _ = Describe("service keys", func() {
 					AddReportEntry(experiment.Name, experiment)
 
 					workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-						experiment.Sample(func(idx int) {
+						helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 							experiment.MeasureDuration("POST /v3/service_credential_bindings", func() {
 								serviceKeyName := fmt.Sprintf("%s-service-key-%s", testConfig.GetNamePrefix(), uuid.NewString())
 								data := fmt.Sprintf(`{"type":"key","name":"%s","relationships":{"service_instance":{"data":{"guid":"%s"}}}}`, serviceKeyName, serviceInstanceGUID)
 
-								exitCode, body := helpers.TimeCFCurlReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/service_credential_bindings")
+								exitCode, body := helpers.TimeCCRequestReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/service_credential_bindings")
 								Expect(exitCode).To(Equal(22))
 								Expect(body).To(ContainSubstring("You have exceeded your organization's limit for service binding of type key."))
 							})
-						}, gmeasure.SamplingConfig{N: testConfig.Samples})
+						})
 					})
 				})
 			})
@@ -57,17 +57,17 @@ var _ = Describe("service keys", func() {
 					AddReportEntry(experiment.Name, experiment)
 
 					workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-						experiment.Sample(func(idx int) {
+						helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 							experiment.MeasureDuration("POST /v3/service_credential_bindings", func() {
 								serviceKeyName := fmt.Sprintf("%s-service-key-%s", testConfig.GetNamePrefix(), uuid.NewString())
 								data := fmt.Sprintf(`{"type":"key","name":"%s","relationships":{"service_instance":{"data":{"guid":"%s"}}}}`, serviceKeyName, serviceInstanceGUID)
 
-								exitCode, body := helpers.TimeCFCurlReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/service_credential_bindings")
+								exitCode, body := helpers.TimeCCRequestReturning(testConfig.BasicTimeout, "-X", "POST", "-d", data, "/v3/service_credential_bindings")
 								Expect(exitCode).To(Equal(0))
 								Expect(body).To(ContainSubstring("202 Accepted"))
 								// Note: The created VCAP::CloudController::V3::CreateBindingAsyncJob fails, as there is no real service broker to handle it.
 							})
-						}, gmeasure.SamplingConfig{N: testConfig.Samples})
+						})
 					})
 				})
 			})
@@ -20,11 +20,11 @@ var _ = Describe("service plans", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/service_plans", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, "/v3/service_plans")
+						helpers.TimeCCRequest(testConfig.BasicTimeout, "/v3/service_plans")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -33,11 +33,11 @@ var _ = Describe("service plans", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/service_plans", func() {
-						helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans?per_page=%d", testConfig.LargePageSize))
+						helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans?per_page=%d", testConfig.LargePageSize))
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 
@@ -46,11 +46,11 @@ var _ = Describe("service plans", func() {
 			AddReportEntry(experiment.Name, experiment)
 
 			workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.LongTimeout, func() {
-				experiment.Sample(func(idx int) {
+				helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 					experiment.MeasureDuration("GET /v3/service_plans", func() {
-						helpers.TimeCFCurl(testConfig.LongTimeout, "/v3/service_plans")
+						helpers.TimeCCRequest(testConfig.LongTimeout, "/v3/service_plans")
 					})
-				}, gmeasure.SamplingConfig{N: testConfig.Samples})
+				})
 			})
 		})
 	})
@@ -62,13 +62,13 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanGUID := getRandomLimitedServicePlanGuid()
 
 						experiment.MeasureDuration("GET /v3/service_plans/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s", servicePlanGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s", servicePlanGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -79,13 +79,13 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						servicePlanGUID := getRandomLimitedServicePlanGuid()
 
 						experiment.MeasureDuration("GET /v3/service_plans/:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s", servicePlanGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s", servicePlanGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -98,13 +98,13 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						var servicePlanGUID = getRandomLimitedServicePlanGuid()
 
 						experiment.MeasureDuration("GET /v3/service_plans/:guid/visibility", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s/visibility", servicePlanGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s/visibility", servicePlanGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -115,13 +115,13 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						var servicePlanGUID = getRandomLimitedServicePlanGuid()
 
 						experiment.MeasureDuration("GET /v3/service_plans/:guid/visibility", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s/visibility", servicePlanGUID))
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf("/v3/service_plans/%s/visibility", servicePlanGUID))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -134,14 +134,14 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						serviceOfferingGuidsList := getRandomServiceOfferingGUIDs()
 
 						experiment.MeasureDuration("GET /v3/service_plans?service_offering_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?service_offering_guids=%v", strings.Join(serviceOfferingGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
@@ -150,15 +150,15 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						serviceOfferingGuidsList := getRandomServiceOfferingGUIDs()
 
 						experiment.MeasureDuration("GET /v3/service_plans?service_offering_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?service_offering_guids=%v&per_page=%d",
 								strings.Join(serviceOfferingGuidsList[:], ","), testConfig.LargePageSize))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -169,14 +169,14 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						serviceOfferingGuidsList := getRandomServiceOfferingGUIDs()
 
 						experiment.MeasureDuration("GET /v3/service_plans?service_offering_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?service_offering_guids=%v", strings.Join(serviceOfferingGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -189,14 +189,14 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						serviceInstanceGuidsList := getRandomServiceInstanceGUIDs()
 
 						experiment.MeasureDuration("GET /v3/service_plans?service_instances_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?service_instance_guids=%v", strings.Join(serviceInstanceGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -207,14 +207,14 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						serviceInstanceGuidsList := getRandomServiceInstanceGUIDs()
 
 						experiment.MeasureDuration("GET /v3/service_plans?service_instances_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?service_instance_guids=%v", strings.Join(serviceInstanceGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -227,7 +227,7 @@ var _ = Describe("service plans", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.RegularUserContext(), testConfig.BasicTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						var orgGuidsList []string = nil
 						selectOrgGuidsStatement := fmt.Sprintf("SELECT organizations.guid FROM organizations JOIN selected_orgs ON organizations.id = selected_orgs.id ORDER BY %s LIMIT 50", helpers.GetRandomFunction(testConfig))
 						orgGuids := helpers.ExecuteSelectStatement(ccdb, ctx, selectOrgGuidsStatement)
@@ -245,10 +245,10 @@ var _ = Describe("service plans", func() {
 						Expect(len(spaceGuidsList)).To(Equal(50))
 
 						experiment.MeasureDuration("GET /v3/service_plans?organization_guids=:guid&space_guids=:guid", func() {
-							helpers.TimeCFCurl(testConfig.BasicTimeout, fmt.Sprintf(
+							helpers.TimeCCRequest(testConfig.BasicTimeout, fmt.Sprintf(
 								"/v3/service_plans?organization_guids=%v&space_guids=%v", strings.Join(orgGuidsList[:], ","), strings.Join(spaceGuidsList[:], ",")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 
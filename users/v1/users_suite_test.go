@@ -3,7 +3,6 @@ package users
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"testing"
 
@@ -18,6 +17,7 @@ import (
 )
 
 var testConfig = helpers.NewConfig()
+var dataset *helpers.Dataset
 var testSetup *workflowhelpers.ReproducibleTestSuiteSetup
 var ccdb *sql.DB
 var uaadb *sql.DB
@@ -27,20 +27,17 @@ var space_guid = uuid.NewString()
 
 const test_version = "v1"
 
-const (
-	// main test parameters:
-	users = 10000
-)
-
 var _ = BeforeSuite(func() {
+	dataset = helpers.LoadDataset(testConfig)
+
 	testSetup = workflowhelpers.NewTestSuiteSetup(&testConfig)
 	testSetup.Setup()
 	ccdb, uaadb, ctx = helpers.OpenDbConnections(testConfig)
-	helpers.ImportStoredProcedures(ccdb, ctx, testConfig)
+	helpers.RequireSeederSchemaVersion()
+	seeder := helpers.NewSeeder(ccdb, ctx, testConfig)
 
 	// create users with org and space roles
-	createUsersWithOrgAndSpaceRolesStatement := fmt.Sprintf("create_users_with_org_and_space_roles('%s', '%s', %d)", org_guid, space_guid, users)
-	helpers.ExecuteStoredProcedure(ccdb, ctx, createUsersWithOrgAndSpaceRolesStatement, testConfig)
+	dataset.Seed(seeder, map[string]string{"org": org_guid, "space": space_guid})
 
 	helpers.AnalyzeDB(ccdb, ctx, testConfig)
 })
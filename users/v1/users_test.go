@@ -19,13 +19,13 @@ var _ = Describe("users", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/organizations/:guid/users", func() {
-							_, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, fmt.Sprintf("/v3/organizations/%s/users", org_guid))
+							_, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, fmt.Sprintf("/v3/organizations/%s/users", org_guid))
 							response := helpers.ParseResponseBody(helpers.RemoveDebugOutput(body))
-							Expect(response.Pagination.TotalResults).To(Equal(users))
+							Expect(response.Pagination.TotalResults).To(Equal(dataset.Int("users")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})
@@ -40,13 +40,13 @@ var _ = Describe("users", func() {
 				AddReportEntry(experiment.Name, experiment)
 
 				workflowhelpers.AsUser(testSetup.AdminUserContext(), testConfig.LongTimeout, func() {
-					experiment.Sample(func(idx int) {
+					helpers.SampleExperiment(experiment, testConfig, func(idx int) {
 						experiment.MeasureDuration("GET /v3/spaces/:guid/users", func() {
-							_, body := helpers.TimeCFCurlReturning(testConfig.LongTimeout, fmt.Sprintf("/v3/spaces/%s/users", space_guid))
+							_, body := helpers.TimeCCRequestReturning(testConfig.LongTimeout, fmt.Sprintf("/v3/spaces/%s/users", space_guid))
 							response := helpers.ParseResponseBody(helpers.RemoveDebugOutput(body))
-							Expect(response.Pagination.TotalResults).To(Equal(users))
+							Expect(response.Pagination.TotalResults).To(Equal(dataset.Int("users")))
 						})
-					}, gmeasure.SamplingConfig{N: testConfig.Samples})
+					})
 				})
 			})
 		})